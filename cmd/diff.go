@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/treediff"
+)
+
+// diffColors are the SGR parameters of the values of the nodes that differ, when the drawing is colored.
+var diffColors = map[treediff.Change]string{
+	treediff.Added:   "1;32",
+	treediff.Removed: "1;31",
+	treediff.Changed: "1;33",
+}
+
+// differences is returned when the compared trees differ, so that the command exits with status 1, as diff(1) does.
+// The message is the summary of the differences.
+type differences treediff.Summary
+
+func (d differences) Error() string {
+	return fmt.Sprintf("%d added, %d removed, %d changed", d.Added, d.Removed, d.Changed)
+}
+
+// diffTrouble is an error that keeps diff from comparing the trees, e.g. a file that can't be read or parsed.
+// It's reported with exit status 2, as diff(1) does, so it isn't taken for trees that differ.
+type diffTrouble struct {
+	err error
+}
+
+func (d diffTrouble) Error() string {
+	return d.err.Error()
+}
+
+func (d diffTrouble) Unwrap() error {
+	return d.err
+}
+
+// diffMode handles "diff [-collapse] OLD NEW". Both files are read in the format given by -from or by their extensions.
+// The merged tree is written in the output format, with the nodes that differ colored if the drawing is, and if the trees differ,
+// the summary of the differences is returned as an error.
+func diffMode(args []string, from string, stdout io.Writer, out *output) error {
+	var opts treediff.Options
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.BoolVar(&opts.Collapse, "collapse", false, "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 2 {
+		return errUsage
+	}
+
+	var trees [2]*printer.Node
+	for i, filename := range flags.Args() {
+		if filename == "-" {
+			return usageError("diff reads both trees from files, not from stdin")
+		}
+		root, err := fileTree([]string{filename}, from, nil)
+		if err != nil {
+			// Read errors name the file already; parse errors don't, and there are two files.
+			if !errors.As(err, new(*fs.PathError)) {
+				err = fmt.Errorf("%s: %w", filename, err)
+			}
+			return diffTrouble{err}
+		}
+		trees[i] = root
+	}
+
+	root, summary, changes := treediff.DiffChanges(trees[0], trees[1], opts)
+	if root != nil {
+		out.highlights = map[*printer.Node]string{}
+		for n, change := range changes {
+			out.highlights[n] = diffColors[change]
+		}
+		if err := out.write(stdout, root); err != nil {
+			return diffTrouble{err}
+		}
+	}
+	if summary != (treediff.Summary{}) {
+		return differences(summary)
+	}
+	return nil
+}
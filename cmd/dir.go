@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/dirtree"
+)
+
+// patternList is a flag that can be repeated, and also takes comma-separated values.
+type patternList []string
+
+func (p *patternList) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *patternList) Set(value string) error {
+	*p = append(*p, strings.Split(value, ",")...)
+	return nil
+}
+
+// dirMode handles "dir [FLAGS] PATH". A directory is not a binary tree, so the entries are printed right away, either drawn as an n-ary tree
+// (in the style given by -style and cut by -width) or, with -outline, one per line. No other output formats are supported.
+func dirMode(args []string, stdout io.Writer, out *output) error {
+	var opts dirtree.Options
+	var include, exclude patternList
+	var outline bool
+	flags := flag.NewFlagSet("dir", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&opts.MaxDepth, "depth", 0, "")
+	flags.Var(&include, "include", "")
+	flags.Var(&exclude, "exclude", "")
+	flags.BoolVar(&opts.Hidden, "hidden", false, "")
+	flags.BoolVar(&opts.Sizes, "size", false, "")
+	flags.BoolVar(&outline, "outline", false, "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+	if out.format != drawingFormat || out.orientation != "down" {
+		return usageError("dir draws the hierarchy top down, -to and -orientation are not supported")
+	}
+	opts.Include, opts.Exclude = include, exclude
+
+	dir := flags.Arg(0)
+	root, err := dirtree.Read(os.DirFS(dir), dir, opts)
+	if err != nil {
+		return err
+	}
+
+	if outline {
+		_, err = io.WriteString(stdout, root.Outline(opts.Sizes))
+		return err
+	}
+	for _, line := range printer.DrawNary(root.Nary(opts.Sizes), styles[out.style]) {
+		if _, err := io.WriteString(stdout, cut(line, out.width)+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/document"
+)
+
+// documentTree handles "json [FLAGS] [FILE]" and "yaml [FLAGS] [FILE]".
+func documentTree(format string, args []string, stdin io.Reader) (*printer.Node, error) {
+	fields := document.DefaultFields
+	flags := flag.NewFlagSet(format, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&fields.Value, "value", fields.Value, "")
+	flags.StringVar(&fields.Left, "left", fields.Left, "")
+	flags.StringVar(&fields.Right, "right", fields.Right, "")
+	if err := flags.Parse(args); err != nil {
+		return nil, errUsage
+	}
+
+	data, err := readInput(flags.Args(), stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "yaml" {
+		return document.ParseYAML(data, fields)
+	}
+	return document.ParseJSON(data, fields)
+}
+
+// jsonStructureTree handles "jsontree [-max N] [FILE]".
+func jsonStructureTree(args []string, stdin io.Reader) (*printer.Node, error) {
+	var opts document.StructureOptions
+	flags := flag.NewFlagSet("jsontree", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&opts.MaxString, "max", 32, "")
+	if err := flags.Parse(args); err != nil {
+		return nil, errUsage
+	}
+
+	data, err := readInput(flags.Args(), stdin)
+	if err != nil {
+		return nil, err
+	}
+	return document.Structure(data, opts)
+}
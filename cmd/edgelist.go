@@ -0,0 +1,18 @@
+package main
+
+import (
+	"bytes"
+	"io"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/edgelist"
+)
+
+// edgeListTree handles "edges [FILE]".
+func edgeListTree(args []string, stdin io.Reader) (*printer.Node, error) {
+	data, err := readInput(args, stdin)
+	if err != nil {
+		return nil, err
+	}
+	return edgelist.Parse(bytes.NewReader(data), edgelist.Options{})
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/explorer"
+)
+
+// openTerminal opens the terminal used by -explore and returns it with the function restoring it. Tests replace it with a fake one.
+var openTerminal = func() (explorer.Terminal, func() error, error) {
+	tty, err := explorer.OpenTTY()
+	if err != nil {
+		return nil, nil, err
+	}
+	return tty, tty.Close, nil
+}
+
+// explore shows the tree in the interactive explorer, with the nodes deeper than expandDepth levels collapsed.
+func explore(root *printer.Node, expandDepth int) (err error) {
+	term, restore, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if restoreErr := restore(); err == nil {
+			err = restoreErr
+		}
+	}()
+	return explorer.Run(term, root, expandDepth)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/document"
+	"github.com/ZupkaPomidorowa/print-tree/drawing"
+	"github.com/ZupkaPomidorowa/print-tree/edgelist"
+	"github.com/ZupkaPomidorowa/print-tree/levelorder"
+	"github.com/ZupkaPomidorowa/print-tree/newick"
+	"github.com/ZupkaPomidorowa/print-tree/outline"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"gopkg.in/yaml.v3"
+)
+
+// drawingFormat is the default output format: the picture drawn by the printer.
+const drawingFormat = "drawing"
+
+// inputFormats holds the parsers of the formats accepted by -from.
+var inputFormats = map[string]func(data []byte) (*printer.Node, error){
+	"json": func(data []byte) (*printer.Node, error) {
+		return document.ParseJSON(data, document.DefaultFields)
+	},
+	"yaml": func(data []byte) (*printer.Node, error) {
+		return document.ParseYAML(data, document.DefaultFields)
+	},
+	"sexpr": func(data []byte) (*printer.Node, error) {
+		return sexpr.Parse(string(data))
+	},
+	"newick": func(data []byte) (*printer.Node, error) {
+		return newick.Parse(string(data))
+	},
+	"levelorder": func(data []byte) (*printer.Node, error) {
+		return levelorder.Parse(string(data))
+	},
+	"outline": func(data []byte) (*printer.Node, error) {
+		return outline.Parse(string(data))
+	},
+	"edges": func(data []byte) (*printer.Node, error) {
+		return edgelist.Parse(bytes.NewReader(data), edgelist.Options{})
+	},
+	drawingFormat: func(data []byte) (*printer.Node, error) {
+		return drawing.Parse(string(data))
+	},
+}
+
+// extensionFormats maps file extensions to the input formats used when -from is not given.
+var extensionFormats = map[string]string{
+	".json":   "json",
+	".yaml":   "yaml",
+	".yml":    "yaml",
+	".sexp":   "sexpr",
+	".nwk":    "newick",
+	".newick": "newick",
+	".csv":    "edges",
+	".tsv":    "edges",
+}
+
+// outputFormats holds the formatters of the formats accepted by -to, except the drawing, which is written line by line.
+// The formats showing the drawing (svg, html and layout) draw it with the given options.
+var outputFormats = map[string]func(root *printer.Node, opts printer.DrawOptions) (string, error){
+	"json": func(root *printer.Node, _ printer.DrawOptions) (string, error) {
+		data, err := json.MarshalIndent(root, "", "  ")
+		return string(data) + "\n", err
+	},
+	"yaml": func(root *printer.Node, _ printer.DrawOptions) (string, error) {
+		data, err := yaml.Marshal(root)
+		return string(data), err
+	},
+	"sexpr": func(root *printer.Node, _ printer.DrawOptions) (string, error) {
+		return sexpr.Format(root) + "\n", nil
+	},
+	"newick": func(root *printer.Node, _ printer.DrawOptions) (string, error) {
+		text, err := newick.Format(root)
+		return text + "\n", err
+	},
+	"levelorder": func(root *printer.Node, _ printer.DrawOptions) (string, error) {
+		return levelorder.Format(root) + "\n", nil
+	},
+	"outline": func(root *printer.Node, _ printer.DrawOptions) (string, error) {
+		return outline.Format(root), nil
+	},
+	"svg":    svgFormat,
+	"html":   htmlFormat,
+	"layout": layoutFormat,
+}
+
+// inputFormat returns the parser of the named format, or of the format detected from the extension of the file if the name is empty.
+func inputFormat(name, filename string) (func([]byte) (*printer.Node, error), error) {
+	if name == "" {
+		name = extensionFormats[strings.ToLower(filepath.Ext(filename))]
+		if name == "" {
+			return nil, fmt.Errorf("unknown format of %q, use -from with one of: %s", filename, formatNames(inputFormats))
+		}
+	}
+	parse, ok := inputFormats[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown input format %q, expected one of: %s", name, formatNames(inputFormats))
+	}
+	return parse, nil
+}
+
+func formatNames[F any](formats map[string]F) string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/goast"
+)
+
+// goExprTree handles "goexpr EXPR".
+func goExprTree(args []string) (*printer.Node, error) {
+	if len(args) != 1 {
+		return nil, errUsage
+	}
+	return goast.ParseExpr(args[0])
+}
+
+// goASTTree handles "goast FILE.go:FUNC". The function name is after the last colon, as the path may contain colons (e.g. C:\src\a.go).
+func goASTTree(args []string) (*printer.Node, error) {
+	if len(args) != 1 {
+		return nil, errUsage
+	}
+	i := strings.LastIndex(args[0], ":")
+	if i < 0 || i == len(args[0])-1 {
+		return nil, errUsage
+	}
+	return goast.ParseFunc(args[0][:i], nil, args[0][i+1:])
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"io"
+	"os"
+)
+
+// readInput reads the whole input of a mode: the file given as the only argument, or stdin if there's no argument or the argument is "-".
+func readInput(args []string, stdin io.Reader) ([]byte, error) {
+	switch {
+	case len(args) > 1:
+		return nil, errUsage
+	case len(args) == 0 || args[0] == "-":
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(args[0])
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/levelorder"
+)
+
+// levelOrderTree handles "levelorder [ARRAY]".
+func levelOrderTree(args []string, stdin io.Reader) (*printer.Node, error) {
+	switch len(args) {
+	case 0:
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		return levelorder.Parse(string(data))
+	case 1:
+		return levelorder.Parse(args[0])
+	}
+	return nil, errUsage
+}
@@ -1,16 +1,282 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
 
 	printer "github.com/ZupkaPomidorowa/print-tree"
 )
 
+// usage lists the modes and the flags of the command. Without arguments, a sample tree is printed.
+const usage = `usage:
+  print-tree [FLAGS]                  print a sample tree
+  print-tree [FLAGS] [FILE]           print a tree from FILE, in the format given by -from or by the file extension
+                                      (stdin if FILE is omitted or "-", which requires -from)
+  print-tree -watch [FLAGS] FILE      print a tree from FILE again whenever the file changes
+  print-tree [FLAGS] MODE [ARGS]      print a tree built by one of the modes:
+
+  goexpr EXPR                         the syntax tree of a Go expression
+  goast FILE.go:FUNC                  the syntax tree of a Go function (or Type.Method)
+  json [FLAGS] [FILE]                 a tree from a JSON document (stdin if FILE is omitted or "-")
+  yaml [FLAGS] [FILE]                 a tree from a YAML document (stdin if FILE is omitted or "-")
+  jsontree [-max N] [FILE]            the structure of any JSON document, with strings cut after N characters
+                                      (default 32, 0 for no limit; stdin if FILE is omitted or "-")
+  xml [FLAGS] [FILE]                  the elements of an XML document (stdin if FILE is omitted or "-")
+  levelorder [ARRAY]                  a tree from a level-order array, e.g. "[1,2,null,3,4]" (stdin if ARRAY is omitted)
+  outline [FILE]                      a tree from an indented outline, with optional "L: " and "R: " side markers
+                                      (stdin if FILE is omitted or "-")
+  edges [FILE]                        a tree from an edge list in CSV or TSV, with "id,parent,side,label" rows
+                                      (stdin if FILE is omitted or "-")
+  template [FILE]                     the parse tree of a text/template or html/template file (stdin if FILE is omitted or "-")
+  procs [-root PID] [-user NAME]      the hierarchy of processes from /proc, starting at PID, with only the processes
+                                      of the user NAME (or UID) and their ancestors
+  modgraph [-depth N] [-versions=false] [FILE]
+                                      the output of "go mod graph" as a tree rooted at the main module, with at most
+                                      N levels of requirements; repeated modules are marked with (*) (stdin if FILE is omitted or "-")
+  regex PATTERN                       the parsed and the simplified syntax trees of a regular expression, side by side
+  dir [FLAGS] PATH                    a directory hierarchy, with all the entries of a directory below it
+                                      (only -style and -width apply)
+  diff [-collapse] OLD NEW            the differences between two trees read from files, aligned by structure: nodes only in NEW
+                                      are marked with + (green), nodes only in OLD with - (red), changed values are shown as
+                                      old->new (yellow); -collapse replaces unchanged subtrees with (=); if the trees differ,
+                                      the numbers of differences are printed to stderr and the exit status is 1;
+                                      if a tree can't be read, the exit status is 2, as with diff(1)
+  serve [-addr ADDR] [-max-bytes N] [-max-nodes N] [-max-depth N]
+                                      serve the rendering over HTTP (default address :8080): POST a tree to
+                                      /render?from=FORMAT&to=FORMAT, with optional style, orientation and width parameters;
+                                      bodies over -max-bytes (default 1048576) and trees with more than -max-nodes nodes
+                                      (default 10000), more than -max-depth levels (default 256) or too big to draw are rejected
+  repl [SCRIPT]                       build and edit a tree with commands read from stdin, e.g. "set root +", "add L 1",
+                                      "replace LR foo", "delete R" or "undo" (type help for all of them), printing the tree
+                                      after every change; "save FILE" saves the commands as a script, which repl SCRIPT replays
+                                      (scripts may contain only the set, add, replace and delete commands)
+  traversal -in SEQ (-pre SEQ | -post SEQ)
+                                      a tree reconstructed from its inorder and preorder (or postorder) sequences,
+                                      with values separated by commas or spaces, e.g. -in "4,2,5,1,3"
+
+flags:
+  -from FORMAT                        input format: drawing, edges, json, levelorder, newick, outline, sexpr or yaml
+                                      (detected from the extensions .csv, .tsv, .json, .newick, .nwk, .sexp, .yaml and .yml)
+  -to FORMAT                          output format: drawing (default), html, json, layout (the lines of the drawing with the
+                                      positions of the values, as JSON), levelorder, newick, outline, sexpr, svg or yaml
+  -style STYLE                        connectors of a drawing: ascii (default) or unicode
+  -orientation DIR                    root of a drawing at the top (down, default) or at the bottom (up)
+  -width N                            cut the lines of a drawing after N columns (default 0, no limit)
+  -color WHEN                         color a drawing: auto (default, if stdout is a terminal and NO_COLOR is not set),
+                                      always or never
+  -theme THEME                        colors of a drawing: default, forest, mono or ocean
+  -watch                              render FILE again whenever it changes, until interrupted; errors are shown in place of the tree
+  -interval DURATION                  how often -watch checks FILE for changes (default 500ms)
+  -explore                            browse the tree interactively in the terminal: move with the arrow keys, collapse and
+                                      expand subtrees with enter, search with /, quit with q (Linux only)
+  -expand N                           with -explore, collapse the nodes more than N levels below the root (default 3, 0 for none)
+  -h, --help                          print this help
+
+dir flags:
+  -depth N                            show at most N levels below PATH
+  -include GLOB, -exclude GLOB        show only the matching files, skip the matching entries (repeatable, comma-separated)
+  -hidden                             show the entries with names starting with a dot
+  -size                               show the sizes of files and directories
+  -outline                            print one entry per line, indented, instead of drawing the tree
+
+xml flags:
+  -attrs                              show the attributes as the first children of the elements
+  -depth N                            show at most N levels of elements below the root element
+  -text N                             cut text and attribute values after N characters (default 32, 0 for no limit)
+  -ns MODE                            show namespaces: hidden (default), prefix or uri
+
+document flags (json, yaml):
+  -value NAME, -left NAME, -right NAME
+                                      names of the node fields (default "value", "left" and "right")
+
+exit status: 0 on success, 1 on errors (and on different trees with diff), 2 on invalid arguments
+(and on trees that can't be read with diff)`
+
+// errUsage is returned for invalid command line arguments.
+var errUsage = errors.New(usage)
+
+// usageError is an invalid argument that is better explained by a short message than by the usage.
+// It still matches errUsage, so it's reported with the same exit code.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func (e usageError) Is(target error) bool {
+	return target == errUsage
+}
+
+// Exit codes of the command.
+const (
+	exitOK      = 0
+	exitError   = 1
+	exitUsage   = 2
+	exitTrouble = 2 // diff couldn't compare the trees; diff(1) uses the same status
+)
+
 func main() {
-	fmt.Println(bigTree())
+	os.Exit(report(run(os.Args[1:], os.Stdin, os.Stdout), os.Stderr))
+}
+
+// report prints the error returned by run to stderr and returns the exit code.
+func report(err error, stderr io.Writer) int {
+	if err == nil {
+		return exitOK
+	}
+	fmt.Fprintln(stderr, err)
+	if errors.Is(err, errUsage) {
+		return exitUsage
+	}
+	if errors.As(err, new(diffTrouble)) {
+		return exitTrouble
+	}
+	return exitError
+}
+
+// run executes the command line arguments (without the program name) and prints the tree to stdout.
+// The global flags come first, followed by a mode and its arguments, or by an input file.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	out := defaultOutput()
+	var from string
+	var watchFile, interactive bool
+	var expandDepth int
+	var watchInterval time.Duration
+	flags := flag.NewFlagSet("print-tree", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&from, "from", "", "")
+	flags.StringVar(&out.format, "to", out.format, "")
+	flags.StringVar(&out.style, "style", out.style, "")
+	flags.StringVar(&out.orientation, "orientation", out.orientation, "")
+	flags.IntVar(&out.width, "width", out.width, "")
+	flags.StringVar(&out.color, "color", out.color, "")
+	flags.StringVar(&out.theme, "theme", out.theme, "")
+	flags.BoolVar(&watchFile, "watch", false, "")
+	flags.DurationVar(&watchInterval, "interval", defaultWatchInterval, "")
+	flags.BoolVar(&interactive, "explore", false, "")
+	flags.IntVar(&expandDepth, "expand", 3, "")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			_, err = fmt.Fprintln(stdout, usage)
+			return err
+		}
+		return errUsage
+	}
+	if err := out.validate(); err != nil {
+		return usageError(err.Error())
+	}
+	args = flags.Args()
+
+	if watchFile {
+		if len(args) != 1 || args[0] == "-" || isMode(args[0]) || watchInterval <= 0 {
+			return usageError("-watch requires a single input FILE and a positive -interval")
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return watch(ctx, args[0], watchInterval, stdout, func() (string, error) {
+			root, err := fileTree(args, from, stdin)
+			if err != nil {
+				return "", err
+			}
+			return out.render(root, stdout)
+		})
+	}
+
+	var root *printer.Node
+	var err error
+	mode := ""
+	if len(args) > 0 {
+		mode = args[0]
+	}
+	switch mode {
+	case "goexpr":
+		root, err = goExprTree(args[1:])
+	case "goast":
+		root, err = goASTTree(args[1:])
+	case "json", "yaml":
+		root, err = documentTree(args[0], args[1:], stdin)
+	case "jsontree":
+		root, err = jsonStructureTree(args[1:], stdin)
+	case "xml":
+		root, err = xmlTree(args[1:], stdin)
+	case "levelorder":
+		root, err = levelOrderTree(args[1:], stdin)
+	case "modgraph":
+		root, err = modGraphTree(args[1:], stdin)
+	case "procs":
+		root, err = procsTree(args[1:])
+	case "template":
+		root, err = templateTree(args[1:], stdin)
+	case "traversal":
+		root, err = traversalTree(args[1:])
+	case "outline":
+		root, err = outlineTree(args[1:], stdin)
+	case "edges":
+		root, err = edgeListTree(args[1:], stdin)
+	case "dir":
+		return dirMode(args[1:], stdout, &out)
+	case "regex":
+		return regexMode(args[1:], stdout, &out)
+	case "serve":
+		return serveMode(args[1:], stdout)
+	case "repl":
+		root, err = replTree(args[1:], stdin, stdout, &out)
+	case "diff":
+		return diffMode(args[1:], from, stdout, &out)
+	default:
+		if len(args) == 0 && from == "" {
+			root = sampleTree()
+		} else {
+			root, err = fileTree(args, from, stdin)
+		}
+	}
+	if err != nil || root == nil {
+		return err
+	}
+
+	if interactive {
+		return explore(root, expandDepth)
+	}
+	return out.write(stdout, root)
+}
+
+// modes lists the modes handled by run.
+var modes = []string{"goexpr", "goast", "json", "yaml", "jsontree", "xml", "levelorder", "modgraph", "procs", "template", "traversal", "outline", "edges", "dir", "regex", "serve", "repl", "diff"}
+
+func isMode(arg string) bool {
+	return slices.Contains(modes, arg)
+}
+
+// fileTree reads a tree from the file given as the only argument, or from stdin, in the given format.
+func fileTree(args []string, from string, stdin io.Reader) (*printer.Node, error) {
+	filename := "-"
+	if len(args) > 0 {
+		filename = args[0]
+	}
+	if filename == "-" && from == "" {
+		return nil, usageError("reading stdin requires -from FORMAT")
+	}
+	parse, err := inputFormat(from, filename)
+	if err != nil {
+		return nil, usageError(err.Error())
+	}
+
+	data, err := readInput(args, stdin)
+	if err != nil {
+		return nil, err
+	}
+	return parse(data)
 }
 
-func bigTree() string {
+// sampleTree returns the tree printed when there are no arguments.
+func sampleTree() *printer.Node {
 	//Leaf nodes
 	n1 := &printer.Node{
 		Value: "1",
@@ -73,5 +339,5 @@ func bigTree() string {
 		RightChild: rightRootChild,
 	}
 
-	return printer.PrintTree(root).String()
+	return root
 }
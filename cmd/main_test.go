@@ -0,0 +1,571 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/explorer"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/ZupkaPomidorowa/print-tree/traversal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunSampleTree(t *testing.T) {
+	var stdout bytes.Buffer
+	err := run(nil, nil, &stdout)
+
+	assert.NoError(t, err)
+	assert.Equal(t, printer.PrintTree(sampleTree()).String(), stdout.String())
+}
+
+func TestRunGoExpr(t *testing.T) {
+	var stdout bytes.Buffer
+	err := run([]string{"goexpr", "a*b"}, nil, &stdout)
+
+	assert.NoError(t, err)
+	assert.Equal(t, render.Nlnl(`
+    *
+   / \
+  /   \
+ /     \
+a       b
+`), stdout.String())
+}
+
+func TestRunGoAST(t *testing.T) {
+	// The path contains a colon, so the function name is after the last one.
+	dir := filepath.Join(t.TempDir(), "a:b")
+	assert.NoError(t, os.Mkdir(dir, 0o755))
+	path := filepath.Join(dir, "f.go")
+	assert.NoError(t, os.WriteFile(path, []byte("package f\n\nfunc F() int { return 1 + 2 }\n"), 0o644))
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"-to", "sexpr", "goast", path + ":F"}, nil, &stdout))
+	assert.Equal(t, "(func (... F (FuncType FieldList (FieldList (Field int)))) ({} (return (+ 1 2))))\n", stdout.String())
+}
+
+func TestRunDispatchesModes(t *testing.T) {
+	defer func(fsys fs.FS) { procFS = fsys }(procFS)
+	procFS = fakeProcFS
+
+	// A mode that run doesn't dispatch is read as an input file, which doesn't exist, so it fails to be opened.
+	for _, mode := range append(modes, "nomode") {
+		args := []string{"-from", "sexpr", "-to", "sexpr", mode}
+		if mode == "serve" {
+			// Without arguments, it would serve until interrupted.
+			args = append(args, "-nosuchflag")
+		}
+		var pathErr *fs.PathError
+		readAsFile := errors.As(run(args, strings.NewReader(""), &bytes.Buffer{}), &pathErr) && pathErr.Path == mode
+		assert.Equal(t, mode == "nomode", readAsFile, mode)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := [][]string{
+		{"-nosuchflag"},
+		{"-style", "fancy"},
+		{"-to", "png"},
+		{"-orientation", "left"},
+		{"-width", "-1"},
+		{"-color", "sometimes"},
+		{"-theme", "neon"},
+		{"-from", "csv", "tree.csv"},
+		{"nosuchmode"},
+		{"-"},
+		{"goexpr"},
+		{"goexpr", "a", "b"},
+		{"goast", "file.go"},
+		{"goast", "file.go:"},
+		{"json", "-nosuchflag"},
+		{"yaml", "a.yaml", "b.yaml"},
+		{"levelorder", "[1]", "[2]"},
+		{"traversal", "-in", "1", "2"},
+		{"outline", "a", "b"},
+		{"edges", "a.csv", "b.csv"},
+		{"dir"},
+		{"jsontree", "-max"},
+		{"regex"},
+		{"-to", "json", "regex", "a|b"},
+		{"procs", "1"},
+		{"modgraph", "-depth"},
+		{"xml", "-depth", "x"},
+		{"template", "a.tmpl", "b.tmpl"},
+		{"serve", "-max-bytes", "0"},
+		{"serve", "extra"},
+		{"dir", "-depth", "x", "."},
+	}
+
+	for _, args := range tests {
+		assert.ErrorIs(t, run(args, strings.NewReader(""), &bytes.Buffer{}), errUsage, args)
+	}
+
+	assert.EqualError(t, run([]string{"goexpr", "a +"}, nil, &bytes.Buffer{}), "1:4: expected operand, found 'EOF'")
+}
+
+func TestRunHelp(t *testing.T) {
+	for _, arg := range []string{"-h", "-help", "--help"} {
+		var stdout bytes.Buffer
+		assert.NoError(t, run([]string{arg}, nil, &stdout), arg)
+		assert.Equal(t, usage+"\n", stdout.String(), arg)
+	}
+}
+
+func TestReport(t *testing.T) {
+	var stderr bytes.Buffer
+	assert.Equal(t, exitOK, report(nil, &stderr))
+	assert.Empty(t, stderr.String())
+
+	assert.Equal(t, exitError, report(errors.New("broken input"), &stderr))
+	assert.Equal(t, "broken input\n", stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, exitUsage, report(run([]string{"-style", "fancy"}, nil, &bytes.Buffer{}), &stderr))
+	assert.Equal(t, "unknown style \"fancy\", expected one of: ascii, unicode\n", stderr.String())
+
+	assert.Equal(t, exitUsage, report(errUsage, &bytes.Buffer{}))
+}
+
+func TestRunFormats(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tree.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"value": "+", "left": {"value": "1"}, "right": {"value": "2"}}`), 0o644))
+
+	tests := []struct {
+		args     []string
+		stdin    string
+		expected string
+	}{
+		{[]string{"-to", "sexpr", path}, "", "(+ 1 2)\n"},
+		{[]string{"-to", "newick", path}, "", "(1,2)+;\n"},
+		{[]string{"-to", "levelorder", path}, "", "[+,1,2]\n"},
+		{[]string{"-to", "outline", path}, "", "+\n  L: 1\n  R: 2\n"},
+		{[]string{"-to", "yaml", path}, "", "value: +\nleft:\n    value: \"1\"\nright:\n    value: \"2\"\n"},
+		{[]string{"-to", "json", "-from", "sexpr"}, "(x () y)", "{\n  \"value\": \"x\",\n  \"right\": {\n    \"value\": \"y\"\n  }\n}\n"},
+		{[]string{"-from", "newick", "-to", "sexpr", "-"}, "(a,b)c;", "(c a b)\n"},
+		{[]string{"-from", "drawing", "-to", "sexpr"}, "    +\n   / \\\n  /   \\\n /     \\\na       b\n", "(+ a b)\n"},
+		{[]string{"-to", "sexpr", "goexpr", "a+b"}, "", "(+ a b)\n"},
+		{[]string{"-to", "html", "-orientation", "up", "-style", "unicode", "-from", "sexpr"}, "(+ a b)", "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>+</title></head>\n<body>\n<pre>a       b\n ╲     ╱\n  ╲   ╱\n   ╲ ╱\n    +</pre>\n</body>\n</html>\n"},
+		{[]string{"-to", "html", "-from", "sexpr"}, "(<b>)", "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>&lt;b&gt;</title></head>\n<body>\n<pre>&lt;b&gt;</pre>\n</body>\n</html>\n"},
+	}
+
+	for _, tt := range tests {
+		var stdout bytes.Buffer
+		if assert.NoError(t, run(tt.args, strings.NewReader(tt.stdin), &stdout), tt.args) {
+			assert.Equal(t, tt.expected, stdout.String(), tt.args)
+		}
+	}
+
+	assert.EqualError(t, run([]string{filepath.Join(dir, "tree.txt")}, nil, &bytes.Buffer{}),
+		`unknown format of "`+filepath.Join(dir, "tree.txt")+`", use -from with one of: drawing, edges, json, levelorder, newick, outline, sexpr, yaml`)
+	assert.EqualError(t, run([]string{"-"}, nil, &bytes.Buffer{}), "reading stdin requires -from FORMAT")
+}
+
+func TestRunDrawingFlags(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"-style", "unicode", "goexpr", "a+b"}, "    +\n   ╱ ╲\n  ╱   ╲\n ╱     ╲\na       b\n"},
+		{[]string{"-orientation", "up", "goexpr", "a+b"}, "a       b\n \\     /\n  \\   /\n   \\ /\n    +\n"},
+		{[]string{"-width", "6", "goexpr", "a+bcde"}, "    +\n   / \\\n  /...\n / ...\na  ...\n"},
+		{[]string{"-color", "always", "-theme", "ocean", "goexpr", "a+b"},
+			"    \x1b[1;36m+\x1b[0m\n   \x1b[34m/ \\\x1b[0m\n  \x1b[34m/   \\\x1b[0m\n \x1b[34m/     \\\x1b[0m\n\x1b[1;36ma       b\x1b[0m\n"},
+		{[]string{"-color", "auto", "goexpr", "a+b"}, "    +\n   / \\\n  /   \\\n /     \\\na       b\n"},
+	}
+
+	for _, tt := range tests {
+		var stdout bytes.Buffer
+		if assert.NoError(t, run(tt.args, nil, &stdout), tt.args) {
+			assert.Equal(t, tt.expected, stdout.String(), tt.args)
+		}
+	}
+}
+
+// fakeTerminal replays the keys and records the screens of the explorer.
+type fakeTerminal struct {
+	*explorer.KeyReader
+	bytes.Buffer
+}
+
+func (ft *fakeTerminal) Size() (int, int, error) {
+	return 40, 10, nil
+}
+
+func TestRunExplore(t *testing.T) {
+	defer func(open func() (explorer.Terminal, func() error, error)) { openTerminal = open }(openTerminal)
+	term := &fakeTerminal{KeyReader: explorer.NewKeyReader(strings.NewReader("jq"))}
+	restored := false
+	openTerminal = func() (explorer.Terminal, func() error, error) {
+		return term, func() error { restored = true; return nil }, nil
+	}
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"-explore", "-expand", "1", "goexpr", "a*b+c"}, nil, &stdout))
+	assert.Empty(t, stdout.String())
+	assert.True(t, restored)
+	assert.Contains(t, term.String(), "*"+explorer.CollapsedMark)
+	assert.Contains(t, term.String(), "\x1b[7m*"+explorer.CollapsedMark+"\x1b[0m")
+}
+
+func TestRunDocuments(t *testing.T) {
+	expected := render.Nlnl(`
+    +
+   / \
+  /   \
+ /     \
+1       2
+`)
+
+	tests := []struct {
+		args  []string
+		stdin string
+	}{
+		{[]string{"json"}, `{"value": "+", "left": {"value": 1}, "right": {"value": 2}}`},
+		{[]string{"json", "-value", "op", "-left", "l", "-right", "r", "-"}, `{"op": "+", "l": {"op": 1}, "r": {"op": 2}}`},
+		{[]string{"yaml"}, "value: +\nleft: {value: 1}\nright: {value: 2}\n"},
+	}
+
+	for _, tt := range tests {
+		var stdout bytes.Buffer
+		err := run(tt.args, strings.NewReader(tt.stdin), &stdout)
+
+		assert.NoError(t, err, tt.args)
+		assert.Equal(t, expected, stdout.String(), tt.args)
+	}
+
+	path := filepath.Join(t.TempDir(), "tree.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("name: +\nleft: {name: 1}\nright: {name: 2}\n"), 0o644))
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"yaml", "-value", "name", path}, nil, &stdout))
+	assert.Equal(t, expected, stdout.String())
+
+	assert.Error(t, run([]string{"json", filepath.Join(t.TempDir(), "missing.json")}, nil, &bytes.Buffer{}))
+}
+
+func TestRunJSONStructure(t *testing.T) {
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"jsontree", "-max", "4"}, strings.NewReader(`{"id": 7, "tags": ["short", "ab"]}`), &stdout))
+	assert.Equal(t, render.Nlnl(`
+            {}
+           /  \
+          /    \
+         /      \
+    id: 7        tags: []
+                /        \
+               /          \
+              /            \
+[0]: "shor..."              [1]: "ab"
+`), stdout.String())
+
+	assert.EqualError(t, run([]string{"jsontree"}, strings.NewReader(`{"id": }`), &bytes.Buffer{}), "1:9: missing value after object key")
+}
+
+func TestRunRegex(t *testing.T) {
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"regex", "x{2}"}, nil, &stdout))
+	assert.Equal(t, render.Nlnl(`
+parsed:                     simplified:
+
+              Repeat {2}                  Concat
+             /                           /      \
+            /                           /        \
+           /                           /          \
+Literal "x"                 Literal "x"            Literal "x"
+`), stdout.String())
+
+	assert.EqualError(t, run([]string{"regex", "a)"}, nil, &bytes.Buffer{}), "error parsing regexp: unexpected ): `a)`")
+}
+
+func TestRunTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "page.tmpl")
+	assert.NoError(t, os.WriteFile(path, []byte(`{{if .}}yes{{end}}`), 0o644))
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"template", path}, nil, &stdout))
+	assert.Equal(t, render.Nlnl(`
+                          list
+                         /
+                        /
+                       /
+                     if
+                    /  \
+                   /    \
+                  /      \
+              pipe        list
+             /           /
+            /           /
+           /           /
+    command       "yes"
+   /
+  /
+ /
+.
+`), stdout.String())
+
+	assert.EqualError(t, run([]string{"template"}, strings.NewReader("{{end}}"), &bytes.Buffer{}), "template: stdin:1: unexpected {{end}}")
+}
+
+func TestRunXML(t *testing.T) {
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"xml", "-attrs", "-ns", "prefix"}, strings.NewReader(`<a:root xmlns:a="urn:a"><a:item n="1">text</a:item></a:root>`), &stdout))
+	assert.Equal(t, render.Nlnl(`
+                   a:root
+                  /      \
+                 /        \
+                /          \
+@xmlns:a="urn:a"            a:item
+                           /      \
+                          /        \
+                         /          \
+                   @n="1"            "text"
+`), stdout.String())
+
+	err := run([]string{"xml", "-ns", "full"}, strings.NewReader(`<a/>`), &bytes.Buffer{})
+	assert.EqualError(t, err, `unknown namespace mode "full", expected hidden, prefix or uri`)
+	assert.ErrorIs(t, err, errUsage)
+	assert.EqualError(t, run([]string{"xml"}, strings.NewReader(`<a>`), &bytes.Buffer{}), "XML syntax error on line 1: unexpected EOF")
+}
+
+// fakeProcFS is a /proc with init and two of its children, sh run by the user 4242 and cron run by root.
+var fakeProcFS = fstest.MapFS{
+	"1/stat":   &fstest.MapFile{Data: []byte("1 (init) S 0 1 1")},
+	"1/status": &fstest.MapFile{Data: []byte("Uid:\t0\t0\t0\t0\n")},
+	"7/stat":   &fstest.MapFile{Data: []byte("7 (sh) S 1 7 7")},
+	"7/status": &fstest.MapFile{Data: []byte("Uid:\t4242\t4242\t4242\t4242\n")},
+	"8/stat":   &fstest.MapFile{Data: []byte("8 (cron) S 1 8 8")},
+	"8/status": &fstest.MapFile{Data: []byte("Uid:\t0\t0\t0\t0\n")},
+	"uptime":   &fstest.MapFile{Data: []byte("1.0 1.0\n")},
+}
+
+func TestRunProcs(t *testing.T) {
+	defer func(fsys fs.FS) { procFS = fsys }(procFS)
+	procFS = fakeProcFS
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"procs", "-user", "4242"}, nil, &stdout))
+	assert.Equal(t, render.Nlnl(`
+       1:init
+      /
+     /
+    /
+7:sh
+`), stdout.String())
+
+	stdout.Reset()
+	assert.NoError(t, run([]string{"procs", "-root", "8"}, nil, &stdout))
+	assert.Equal(t, "8:cron\n", stdout.String())
+
+	assert.EqualError(t, run([]string{"procs", "-root", "9"}, nil, &bytes.Buffer{}), "no process with PID 9")
+}
+
+func TestRunModGraph(t *testing.T) {
+	input := "example.com/app golang.org/x/text@v0.3.7\nexample.com/app example.com/lib@v1.0.0\nexample.com/lib@v1.0.0 golang.org/x/text@v0.3.7\n"
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"modgraph", "-versions=false"}, strings.NewReader(input), &stdout))
+	assert.Equal(t, render.Nlnl(`
+                    example.com/app
+                   /               \
+                  /                 \
+                 /                   \
+golang.org/x/text                     example.com/lib
+                                     /
+                                    /
+                                   /
+                  golang.org/x/text
+`), stdout.String())
+
+	assert.EqualError(t, run([]string{"modgraph"}, strings.NewReader("a\n"), &bytes.Buffer{}), "line 1: expected a module and its requirement, found 1 fields")
+}
+
+func TestRunLevelOrder(t *testing.T) {
+	expected := render.Nlnl(`
+1
+ \
+  \
+   \
+    2
+   /
+  /
+ /
+3
+`)
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"levelorder", "[1,null,2,3]"}, nil, &stdout))
+	assert.Equal(t, expected, stdout.String())
+
+	stdout.Reset()
+	assert.NoError(t, run([]string{"levelorder"}, strings.NewReader("[1,#,2,3]\n"), &stdout))
+	assert.Equal(t, expected, stdout.String())
+}
+
+func TestRunTraversal(t *testing.T) {
+	expected := render.Nlnl(`
+    1
+   / \
+  /   \
+ /     \
+2       3
+`)
+
+	for _, args := range [][]string{
+		{"traversal", "-pre", "1 2 3", "-in", "2 1 3"},
+		{"traversal", "-post", "2,3,1", "-in", "2, 1, 3"},
+	} {
+		var stdout bytes.Buffer
+		assert.NoError(t, run(args, nil, &stdout), args)
+		assert.Equal(t, expected, stdout.String(), args)
+	}
+
+	err := run([]string{"traversal", "-pre", "1"}, nil, &bytes.Buffer{})
+	assert.EqualError(t, err, "traversal: the inorder sequence (-in) is required")
+	assert.ErrorIs(t, err, errUsage)
+	err = run([]string{"traversal", "-in", "1"}, nil, &bytes.Buffer{})
+	assert.EqualError(t, err, "traversal: exactly one of the preorder (-pre) and postorder (-post) sequences is required")
+	assert.ErrorIs(t, err, errUsage)
+	assert.ErrorIs(t, run([]string{"traversal", "-pre", "1 2", "-in", "1 3"}, nil, &bytes.Buffer{}), traversal.ErrInconsistent)
+}
+
+func TestRunOutline(t *testing.T) {
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"outline"}, strings.NewReader("-\n  R: x\n"), &stdout))
+	assert.Equal(t, render.Nlnl(`
+-
+ \
+  \
+   \
+    x
+`), stdout.String())
+
+	assert.EqualError(t, run([]string{"outline", "-"}, strings.NewReader("a\nb\n"), &bytes.Buffer{}), "2:1: more than one root")
+}
+
+func TestRunEdgeList(t *testing.T) {
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"edges"}, strings.NewReader("id,parent,side,label\n1,,,+\n2,1,L,1\n3,1,R,2\n"), &stdout))
+	assert.Equal(t, render.Nlnl(`
+    +
+   / \
+  /   \
+ /     \
+1       2
+`), stdout.String())
+
+	assert.EqualError(t, run([]string{"edges"}, strings.NewReader("1,,,a\n2,,,b\n"), &bytes.Buffer{}), `line 2: node "2" is another root, the root is "1" on line 1`)
+}
+
+func TestRunDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("12345"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.go"), nil, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), nil, 0o644))
+
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"dir", "-outline", "-size", "-exclude", "*.go", dir}, nil, &stdout))
+	assert.Equal(t, dir+"/ (5B)\n  sub/ (5B)\n    a.txt (5B)\n", stdout.String())
+
+	stdout.Reset()
+	assert.NoError(t, run([]string{"dir", "-hidden", "-depth", "1", dir}, nil, &stdout))
+	assert.Contains(t, stdout.String(), ".hidden")
+	assert.NotContains(t, stdout.String(), "a.txt")
+
+	stdout.Reset()
+	assert.NoError(t, run([]string{"-style", "unicode", "dir", filepath.Join(dir, "sub")}, nil, &stdout))
+	lines := strings.Split(stdout.String(), "\n")
+	if assert.Len(t, lines, 5) {
+		assert.Equal(t, filepath.Join(dir, "sub")+"/", lines[0])
+		assert.Equal(t, "╱    ╲", strings.TrimSpace(lines[2]))
+		assert.Equal(t, "a.txt   b.go", strings.TrimSpace(lines[3]))
+	}
+
+	assert.Error(t, run([]string{"dir", filepath.Join(dir, "missing")}, nil, &bytes.Buffer{}))
+	assert.ErrorIs(t, run([]string{"-to", "json", "dir", dir}, nil, &bytes.Buffer{}), errUsage)
+}
+
+func TestRunRepl(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tree.sexp"), []byte("(* a b)"), 0o644))
+	script := filepath.Join(dir, "session.tree")
+
+	input := "load " + filepath.Join(dir, "tree.sexp") + "\nadd LL x\nset root +\nsave " + script + "\n"
+	var stdout bytes.Buffer
+	assert.NoError(t, run([]string{"-to", "sexpr", "repl"}, strings.NewReader(input), &stdout))
+	assert.Equal(t, "> (* a b)\n> (* (a x) b)\n> (+ (a x) b)\n> > \n", stdout.String())
+
+	// The script doesn't depend on the loaded file.
+	assert.NoError(t, os.Remove(filepath.Join(dir, "tree.sexp")))
+	stdout.Reset()
+	assert.NoError(t, run([]string{"-to", "sexpr", "repl", script}, nil, &stdout))
+	assert.Equal(t, "(+ (a x) b)\n", stdout.String())
+
+	assert.NoError(t, os.WriteFile(script, []byte("set root a\ndelete root\n"), 0o644))
+	stdout.Reset()
+	assert.NoError(t, run([]string{"repl", script}, nil, &stdout))
+	assert.Equal(t, "(empty tree)\n", stdout.String())
+
+	assert.NoError(t, os.WriteFile(script, []byte("set root a\nload missing.json\n"), 0o644))
+	assert.ErrorContains(t, run([]string{"repl", script}, nil, &bytes.Buffer{}), script+":2: ")
+	assert.ErrorIs(t, run([]string{"repl", "a.tree", "b.tree"}, nil, &bytes.Buffer{}), errUsage)
+}
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	before, after := filepath.Join(dir, "before.sexp"), filepath.Join(dir, "after.json")
+	assert.NoError(t, os.WriteFile(before, []byte("(+ (* a b) c)"), 0o644))
+	assert.NoError(t, os.WriteFile(after, []byte(`{"value": "+", "left": {"value": "*", "left": {"value": "a"}, "right": {"value": "b"}}, "right": {"value": "d"}}`), 0o644))
+
+	var stdout bytes.Buffer
+	err := run([]string{"-to", "sexpr", "diff", before, after}, nil, &stdout)
+	assert.Equal(t, differences{Changed: 1}, err)
+	assert.Equal(t, "(+ (* a b) c->d)\n", stdout.String())
+
+	var stderr bytes.Buffer
+	assert.Equal(t, exitError, report(err, &stderr))
+	assert.Equal(t, "0 added, 0 removed, 1 changed\n", stderr.String())
+
+	stdout.Reset()
+	assert.Error(t, run([]string{"diff", "-collapse", before, after}, nil, &stdout))
+	assert.Equal(t, "      +\n     / \\\n    /   \\\n   /     \\\n(=)       c->d\n", stdout.String())
+
+	// Only the changed value is colored differently; the unchanged "+" isn't taken for an added node.
+	stdout.Reset()
+	assert.Error(t, run([]string{"-color", "always", "-theme", "mono", "diff", "-collapse", before, after}, nil, &stdout))
+	assert.Equal(t, "      \x1b[1m+\x1b[0m\n     / \\\n    /   \\\n   /     \\\n\x1b[1m(=)\x1b[0m       \x1b[1;33mc->d\x1b[0m\n", stdout.String())
+
+	// Values cut off by -width are not colored.
+	stdout.Reset()
+	assert.Error(t, run([]string{"-color", "always", "-theme", "mono", "-width", "8", "diff", "-collapse", before, after}, nil, &stdout))
+	assert.Equal(t, "      \x1b[1m+\x1b[0m\n     / \\\n    /...\n   / ...\n\x1b[1m(=)\x1b[0m  \x1b[1m...\x1b[0m\n", stdout.String())
+
+	stdout.Reset()
+	assert.NoError(t, run([]string{"-to", "sexpr", "diff", before, before}, nil, &stdout))
+	assert.Equal(t, "(+ (* a b) c)\n", stdout.String())
+
+	assert.ErrorIs(t, run([]string{"diff", before}, nil, &bytes.Buffer{}), errUsage)
+	assert.ErrorIs(t, run([]string{"diff", before, "-"}, nil, &bytes.Buffer{}), errUsage)
+
+	// Trees that can't be read or parsed are told apart from trees that differ, as diff(1) does.
+	broken := filepath.Join(dir, "broken.sexp")
+	assert.NoError(t, os.WriteFile(broken, []byte("(+ a"), 0o644))
+	for _, tt := range []struct {
+		old, new, failing string
+	}{
+		{before, filepath.Join(dir, "missing.json"), "missing.json"},
+		{broken, before, "broken.sexp"},
+	} {
+		stderr.Reset()
+		assert.Equal(t, exitTrouble, report(run([]string{"diff", tt.old, tt.new}, nil, &bytes.Buffer{}), &stderr), tt.failing)
+		assert.Contains(t, stderr.String(), tt.failing)
+	}
+}
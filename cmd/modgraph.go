@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/modgraph"
+)
+
+// modGraphTree handles "modgraph [-depth N] [-versions=false] [FILE]".
+func modGraphTree(args []string, stdin io.Reader) (*printer.Node, error) {
+	var opts modgraph.Options
+	var versions bool
+	flags := flag.NewFlagSet("modgraph", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&opts.MaxDepth, "depth", 0, "")
+	flags.BoolVar(&versions, "versions", true, "")
+	if err := flags.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	opts.HideVersions = !versions
+
+	data, err := readInput(flags.Args(), stdin)
+	if err != nil {
+		return nil, err
+	}
+	g, err := modgraph.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return g.Tree(opts), nil
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"io"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/outline"
+)
+
+// outlineTree handles "outline [FILE]".
+func outlineTree(args []string, stdin io.Reader) (*printer.Node, error) {
+	data, err := readInput(args, stdin)
+	if err != nil {
+		return nil, err
+	}
+	return outline.Parse(string(data))
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// theme holds the SGR parameters (e.g. "1;36" for bold cyan) of the values and the connectors of a drawing. Empty means no color.
+type theme struct {
+	values     string
+	connectors string
+}
+
+var themes = map[string]theme{
+	"default": {values: "1", connectors: "36"},
+	"ocean":   {values: "1;36", connectors: "34"},
+	"forest":  {values: "1;32", connectors: "33"},
+	"mono":    {values: "1"},
+}
+
+var styles = map[string]printer.Style{
+	"ascii":   printer.StyleASCII,
+	"unicode": printer.StyleUnicode,
+}
+
+var orientations = map[string]printer.Orientation{
+	"down": printer.TopDown,
+	"up":   printer.BottomUp,
+}
+
+// cutMark ends the lines cut because of the width limit.
+const cutMark = "..."
+
+// output holds the global flags that control how a tree is written.
+type output struct {
+	format      string
+	style       string
+	orientation string
+	width       int
+	color       string
+	theme       string
+	// highlights holds the SGR parameters of the values colored differently from the theme, e.g. the differences of two trees.
+	highlights map[*printer.Node]string
+}
+
+// defaultOutput returns the settings used when no flags are given.
+func defaultOutput() output {
+	return output{format: drawingFormat, style: "ascii", orientation: "down", color: "auto", theme: "default"}
+}
+
+// validate checks the names given in the flags.
+func (o *output) validate() error {
+	if _, ok := outputFormats[o.format]; !ok && o.format != drawingFormat {
+		return fmt.Errorf("unknown output format %q, expected one of: %s, %s", o.format, drawingFormat, formatNames(outputFormats))
+	}
+	if _, ok := styles[o.style]; !ok {
+		return fmt.Errorf("unknown style %q, expected one of: %s", o.style, formatNames(styles))
+	}
+	if _, ok := orientations[o.orientation]; !ok {
+		return fmt.Errorf("unknown orientation %q, expected one of: %s", o.orientation, formatNames(orientations))
+	}
+	if o.width < 0 {
+		return fmt.Errorf("invalid width %d", o.width)
+	}
+	if o.color != "auto" && o.color != "always" && o.color != "never" {
+		return fmt.Errorf("unknown color mode %q, expected one of: always, auto, never", o.color)
+	}
+	if _, ok := themes[o.theme]; !ok {
+		return fmt.Errorf("unknown theme %q, expected one of: %s", o.theme, formatNames(themes))
+	}
+	return nil
+}
+
+// render returns the tree as text in the output format.
+func (o *output) render(root *printer.Node, stdout io.Writer) (string, error) {
+	if format, ok := outputFormats[o.format]; ok {
+		return format(root, o.drawOptions())
+	}
+	var sb strings.Builder
+	err := o.draw(&sb, root, stdout)
+	return sb.String(), err
+}
+
+// write writes the tree to stdout in the output format. The drawing is written line by line, without building the whole picture;
+// if it's not styled, turned, cut or colored, it's streamed by the printer itself.
+func (o *output) write(stdout io.Writer, root *printer.Node) error {
+	if _, ok := outputFormats[o.format]; ok {
+		text, err := o.render(root, stdout)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, text)
+		return err
+	}
+	if o.style == "ascii" && o.orientation == "down" && o.width == 0 && !o.colorful(stdout) {
+		_, err := printer.Fprint(stdout, root, printer.WriteOptions{})
+		return err
+	}
+	return o.draw(stdout, root, stdout)
+}
+
+// draw writes the lines of the drawing to w, styled, cut and colored as set by the flags. Colors depend on stdout, see colorful().
+func (o *output) draw(w io.Writer, root *printer.Node, stdout io.Writer) error {
+	colors := theme{}
+	highlighted := map[int][]printer.Placement{}
+	if o.colorful(stdout) {
+		colors = themes[o.theme]
+		if len(o.highlights) > 0 {
+			for _, p := range printer.Place(root, o.drawOptions()) {
+				if _, ok := o.highlights[p.Node]; ok {
+					highlighted[p.Row] = append(highlighted[p.Row], p)
+				}
+			}
+		}
+	}
+
+	row := 0
+	return printer.DrawEach(root, o.drawOptions(), func(line printer.Line) error {
+		text := cut(line.Text, o.width)
+		code := colors.values
+		if line.Connectors {
+			code = colors.connectors
+		}
+		if placements := highlighted[row]; len(placements) > 0 {
+			text = o.highlight(text, len(text) < len(line.Text), placements, code)
+		} else if code != "" && strings.TrimSpace(text) != "" {
+			// Leading spaces are left uncolored, so that the codes don't add anything to lines that are cut or compared.
+			indent := len(text) - len(strings.TrimLeft(text, " "))
+			text = text[:indent] + "\x1b[" + code + "m" + text[indent:] + "\x1b[0m"
+		}
+		row++
+		_, err := io.WriteString(w, text+"\n")
+		return err
+	})
+}
+
+// highlight colors the values of a line of values one by one, the highlighted ones with their own colors and the others with the code.
+// The placements are the highlighted values of the line, from the left. Values cut off from the line are left as they are.
+func (o *output) highlight(text string, isCut bool, placements []printer.Placement, code string) string {
+	visible := len(text)
+	if isCut {
+		visible -= len(cutMark)
+	}
+	var sb strings.Builder
+	column := 0
+	for _, p := range placements {
+		if p.Column+len(p.Node.Value) > visible {
+			break
+		}
+		sb.WriteString(colored(text[column:p.Column], code))
+		sb.WriteString(colored(p.Node.Value, o.highlights[p.Node]))
+		column = p.Column + len(p.Node.Value)
+	}
+	sb.WriteString(colored(text[column:], code))
+	return sb.String()
+}
+
+// colored wraps the values of the text, the parts between the spaces, in the SGR codes. An empty code leaves the text as it is.
+func colored(text, code string) string {
+	if code == "" {
+		return text
+	}
+	var sb strings.Builder
+	for i := 0; i < len(text); {
+		end := i + 1
+		for end < len(text) && (text[end] == ' ') == (text[i] == ' ') {
+			end++
+		}
+		if text[i] == ' ' {
+			sb.WriteString(text[i:end])
+		} else {
+			sb.WriteString("\x1b[" + code + "m" + text[i:end] + "\x1b[0m")
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// drawOptions returns the style and the orientation of the drawing.
+func (o *output) drawOptions() printer.DrawOptions {
+	return printer.DrawOptions{Style: styles[o.style], Orientation: orientations[o.orientation]}
+}
+
+// colorful tells whether the drawing is colored: always, never, or (auto) when stdout is a terminal and NO_COLOR is not set.
+func (o *output) colorful(stdout io.Writer) bool {
+	switch o.color {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := stdout.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// cut shortens the line to the given number of columns, ending it with the cutMark. Zero means no limit.
+func cut(line string, width int) string {
+	if width == 0 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	runes := []rune(line)
+	if width <= len(cutMark) {
+		return string(runes[:width])
+	}
+	return string(runes[:width-len(cutMark)]) + cutMark
+}
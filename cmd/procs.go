@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"io/fs"
+	"os"
+	"os/user"
+	"strconv"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/proctree"
+)
+
+// procFS is the proc file system read by the procs mode. Tests replace it with a fake one.
+var procFS fs.FS = os.DirFS("/proc")
+
+// procsTree handles "procs [-root PID] [-user NAME]".
+func procsTree(args []string) (*printer.Node, error) {
+	var opts proctree.Options
+	var userName string
+	flags := flag.NewFlagSet("procs", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&opts.RootPID, "root", 0, "")
+	flags.StringVar(&userName, "user", "", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return nil, errUsage
+	}
+
+	if userName != "" {
+		u, err := user.Lookup(userName)
+		switch {
+		case err == nil:
+			opts.UID = u.Uid
+		case isNumber(userName):
+			// Users that aren't known locally, e.g. inside a container, can be given by UID.
+			opts.UID = userName
+		default:
+			return nil, err
+		}
+	}
+	return proctree.Tree(procFS, opts)
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ZupkaPomidorowa/print-tree/regextree"
+)
+
+// regexGap is the number of spaces between the trees printed side by side.
+const regexGap = 4
+
+// regexMode handles "regex PATTERN". It prints the parsed and the simplified syntax trees of the pattern side by side,
+// so only the drawing can be the output format.
+func regexMode(args []string, stdout io.Writer, out *output) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	if out.format != drawingFormat {
+		return usageError("regex prints two drawings side by side, -to " + out.format + " is not supported")
+	}
+	re, err := syntax.Parse(args[0], syntax.Perl)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := out.render(regextree.FromRegexp(re), stdout)
+	if err != nil {
+		return err
+	}
+	simplified, err := out.render(regextree.FromRegexp(re.Simplify()), stdout)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(stdout, sideBySide("parsed:\n\n"+parsed, "simplified:\n\n"+simplified))
+	return err
+}
+
+// sideBySide joins the lines of two texts, padding the lines of the left one to the same width.
+func sideBySide(left, right string) string {
+	leftLines := strings.Split(strings.TrimSuffix(left, "\n"), "\n")
+	rightLines := strings.Split(strings.TrimSuffix(right, "\n"), "\n")
+
+	width := 0
+	for _, line := range leftLines {
+		width = max(width, visibleWidth(line))
+	}
+
+	var sb strings.Builder
+	for i := 0; i < max(len(leftLines), len(rightLines)); i++ {
+		var l, r string
+		if i < len(leftLines) {
+			l = leftLines[i]
+		}
+		if i < len(rightLines) {
+			r = rightLines[i]
+		}
+		if r == "" {
+			fmt.Fprintln(&sb, strings.TrimRight(l, " "))
+			continue
+		}
+		fmt.Fprintf(&sb, "%s%s%s\n", l, strings.Repeat(" ", width+regexGap-visibleWidth(l)), r)
+	}
+	return sb.String()
+}
+
+// visibleWidth returns the number of columns taken by the line: the number of runes, without the color escape sequences.
+func visibleWidth(line string) int {
+	width := 0
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\x1b' {
+			// Skip "ESC [ parameters m".
+			for i < len(line) && line[i] != 'm' {
+				i++
+			}
+			continue
+		}
+		if utf8.RuneStart(line[i]) {
+			width++
+		}
+	}
+	return width
+}
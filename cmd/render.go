@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// The size of a character of the SVG drawing, in pixels. Monospace fonts are about 0.6em wide.
+const (
+	svgFontSize   = 14
+	svgCharWidth  = 8.4
+	svgLineHeight = 17
+)
+
+// drawingLines returns the lines of the drawing, and the width of the widest one in columns.
+func drawingLines(root *printer.Node, opts printer.DrawOptions) ([]string, int) {
+	var lines []string
+	width := 0
+	for _, line := range printer.Draw(root, opts) {
+		lines = append(lines, line.Text)
+		width = max(width, utf8.RuneCountInString(line.Text))
+	}
+	return lines, width
+}
+
+// svgFormat returns the drawing as an SVG image with a line of monospace text per line of the drawing.
+func svgFormat(root *printer.Node, opts printer.DrawOptions) (string, error) {
+	lines, width := drawingLines(root, opts)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%.1f" height="%d" font-family="monospace" font-size="%d">`+"\n",
+		float64(width)*svgCharWidth, len(lines)*svgLineHeight, svgFontSize)
+	for i, line := range lines {
+		fmt.Fprintf(&sb, `<text x="0" y="%d" xml:space="preserve">`, (i+1)*svgLineHeight-4)
+		if err := xml.EscapeText(&sb, []byte(line)); err != nil {
+			return "", err
+		}
+		sb.WriteString("</text>\n")
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String(), nil
+}
+
+// htmlFormat returns the drawing as an HTML document with the drawing in a <pre> element.
+func htmlFormat(root *printer.Node, opts printer.DrawOptions) (string, error) {
+	lines, _ := drawingLines(root, opts)
+	return "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>" + html.EscapeString(root.Value) + "</title></head>\n<body>\n<pre>" +
+		html.EscapeString(strings.Join(lines, "\n")) + "</pre>\n</body>\n</html>\n", nil
+}
+
+// layout is the JSON description of a drawing, for clients that draw the tree themselves.
+type layout struct {
+	Width  int          `json:"width"`
+	Height int          `json:"height"`
+	Lines  []string     `json:"lines"`
+	Nodes  []layoutNode `json:"nodes"`
+}
+
+// layoutNode is the position of a node value in the lines, in level order. The row and the column start at 0.
+type layoutNode struct {
+	Value  string `json:"value"`
+	Row    int    `json:"row"`
+	Column int    `json:"column"`
+}
+
+// layoutFormat returns the lines of the drawing with the positions of the node values as JSON.
+func layoutFormat(root *printer.Node, opts printer.DrawOptions) (string, error) {
+	lines, width := drawingLines(root, opts)
+	l := layout{Width: width, Height: len(lines), Lines: lines}
+	for _, p := range printer.Place(root, opts) {
+		l.Nodes = append(l.Nodes, layoutNode{Value: p.Node.Value, Row: p.Row, Column: p.Column})
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	return string(data) + "\n", err
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/repl"
+)
+
+// replTree handles "repl [SCRIPT]". Without a script the commands are read from stdin and the tree is printed after every change;
+// the session ends with nil. With a script, the commands are replayed and the resulting tree is returned to be printed as usual.
+func replTree(args []string, stdin io.Reader, stdout io.Writer, out *output) (*printer.Node, error) {
+	session := &repl.Session{Load: func(path string) (*printer.Node, error) {
+		return fileTree([]string{path}, "", nil)
+	}}
+
+	switch len(args) {
+	case 0:
+		return nil, repl.Run(session, stdin, stdout, func(root *printer.Node) (string, error) {
+			return out.render(root, stdout)
+		})
+	case 1:
+		script, err := os.Open(args[0])
+		if err != nil {
+			return nil, err
+		}
+		defer script.Close()
+		root, err := session.Replay(args[0], script)
+		if err == nil && root == nil {
+			_, err = fmt.Fprintln(stdout, repl.EmptyTree)
+		}
+		return root, err
+	}
+	return nil, errUsage
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// limits bound the work done for a single request. The drawing grows with the depth of the tree times its width,
+// so a small body, e.g. a long chain of nodes, could take a lot of memory and time to draw.
+type limits struct {
+	maxBytes int64 // of the request body
+	maxNodes int   // of the tree
+	maxDepth int   // of the tree, the root is at depth 1
+	maxCells int   // the estimated width times the height of the drawing
+}
+
+// defaultLimits are the limits used when no flags are given.
+var defaultLimits = limits{maxBytes: 1 << 20, maxNodes: 10000, maxDepth: 256, maxCells: 16 << 20}
+
+// Timeouts of the HTTP server.
+const (
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = 30 * time.Second
+	writeTimeout      = 30 * time.Second
+)
+
+// contentTypes maps output formats to the content types of the responses. Other formats are plain text.
+var contentTypes = map[string]string{
+	"json":   "application/json",
+	"layout": "application/json",
+	"yaml":   "application/yaml",
+	"svg":    "image/svg+xml",
+	"html":   "text/html; charset=utf-8",
+}
+
+// serveMode handles "serve [-addr ADDR] [-max-bytes N] [-max-nodes N] [-max-depth N]".
+// It runs until the server fails, e.g. because the address is taken.
+func serveMode(args []string, stdout io.Writer) error {
+	addr := ":8080"
+	l := defaultLimits
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&addr, "addr", addr, "")
+	flags.Int64Var(&l.maxBytes, "max-bytes", l.maxBytes, "")
+	flags.IntVar(&l.maxNodes, "max-nodes", l.maxNodes, "")
+	flags.IntVar(&l.maxDepth, "max-depth", l.maxDepth, "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 || l.maxBytes <= 0 || l.maxNodes <= 0 || l.maxDepth <= 0 {
+		return errUsage
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newHandler(l),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+	}
+	fmt.Fprintf(stdout, "listening on %s\n", addr)
+	return server.ListenAndServe()
+}
+
+// newHandler returns the handler of the HTTP service. Trees are rendered by "POST /render?from=FORMAT&to=FORMAT",
+// with the tree in the body. Bodies and trees over the limits are rejected. The drawing can be changed with the style, orientation
+// and width parameters, named like the flags. Errors are JSON objects, e.g. {"error": {"code": "invalid_input", "message": "..."}}.
+func newHandler(l limits) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/render", func(w http.ResponseWriter, r *http.Request) {
+		renderRequest(w, r, l)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("no such endpoint: %s, use POST /render", r.URL.Path))
+	})
+	return mux
+}
+
+func renderRequest(w http.ResponseWriter, r *http.Request, l limits) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use POST with the tree in the body")
+		return
+	}
+
+	query := r.URL.Query()
+	out := defaultOutput()
+	out.color = "never"
+	for name, value := range map[string]*string{"to": &out.format, "style": &out.style, "orientation": &out.orientation} {
+		if query.Has(name) {
+			*value = query.Get(name)
+		}
+	}
+	if query.Has("width") {
+		if _, err := fmt.Sscan(query.Get("width"), &out.width); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_parameter", fmt.Sprintf("invalid width %q", query.Get("width")))
+			return
+		}
+	}
+	if err := out.validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+	if !query.Has("from") {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", "missing the from parameter with the input format, one of: "+formatNames(inputFormats))
+		return
+	}
+	parse, err := inputFormat(query.Get("from"), "")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, l.maxBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, "request_too_large", fmt.Sprintf("the request body exceeds %d bytes", tooLarge.Limit))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", err.Error())
+		return
+	}
+
+	root, err := parse(data)
+	if err == nil {
+		err = checkValues(root)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_input", err.Error())
+		return
+	}
+	if err := l.check(root); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "tree_too_large", err.Error())
+		return
+	}
+	text, err := out.render(root, io.Discard)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "unsupported_tree", err.Error())
+		return
+	}
+
+	contentType, ok := contentTypes[out.format]
+	if !ok {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	io.WriteString(w, text)
+}
+
+// checkValues returns an error if any node has an empty value, which can't be drawn.
+func checkValues(n *printer.Node) error {
+	if n == nil {
+		return nil
+	}
+	if n.Value == "" {
+		return errors.New("a node has an empty value")
+	}
+	if err := checkValues(n.LeftChild); err != nil {
+		return err
+	}
+	return checkValues(n.RightChild)
+}
+
+// check returns an error if the tree is over the limits. The width of the drawing is estimated from above: a node adds at most
+// its value, the 7 spaces of the minimal distance between its children (Fig. 1 in printer.go) and one for symmetry to their widths.
+func (l limits) check(root *printer.Node) error {
+	nodes, depth, width := 0, 0, 0
+	var walk func(n *printer.Node, level int)
+	walk = func(n *printer.Node, level int) {
+		if n == nil || nodes > l.maxNodes || depth > l.maxDepth {
+			return
+		}
+		nodes++
+		depth = max(depth, level)
+		width += len(n.Value) + 8
+		walk(n.LeftChild, level+1)
+		walk(n.RightChild, level+1)
+	}
+	walk(root, 1)
+
+	switch {
+	case nodes > l.maxNodes:
+		return fmt.Errorf("the tree has more than %d nodes", l.maxNodes)
+	case depth > l.maxDepth:
+		return fmt.Errorf("the tree is deeper than %d levels", l.maxDepth)
+	case width*4*depth > l.maxCells:
+		return fmt.Errorf("the drawing would take more than %d characters", l.maxCells)
+	}
+	return nil
+}
+
+// apiError is the body of an error response.
+type apiError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	var body apiError
+	body.Error.Code, body.Error.Message = code, message
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeRender(t *testing.T) {
+	tests := []struct {
+		query       string
+		body        string
+		contentType string
+		expected    string
+	}{
+		{"from=sexpr", "(+ a b)", "text/plain; charset=utf-8", "    +\n   / \\\n  /   \\\n /     \\\na       b\n"},
+		{"from=sexpr&orientation=up&style=unicode", "(+ a b)", "text/plain; charset=utf-8", "a       b\n ╲     ╱\n  ╲   ╱\n   ╲ ╱\n    +\n"},
+		{"from=newick&to=sexpr", "(a,b)c;", "text/plain; charset=utf-8", "(c a b)\n"},
+		{"from=json&to=levelorder", `{"value": "1", "right": {"value": "2"}}`, "text/plain; charset=utf-8", "[1,null,2]\n"},
+		{"from=sexpr&to=json", "x", "application/json", "{\n  \"value\": \"x\"\n}\n"},
+		{"from=sexpr&to=layout", "x", "application/json", "{\n  \"width\": 1,\n  \"height\": 1,\n  \"lines\": [\n    \"x\"\n  ],\n  \"nodes\": [\n    {\n      \"value\": \"x\",\n      \"row\": 0,\n      \"column\": 0\n    }\n  ]\n}\n"},
+	}
+
+	handler := newHandler(defaultLimits)
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/render?"+tt.query, strings.NewReader(tt.body)))
+		assert.Equal(t, http.StatusOK, recorder.Code, tt.query)
+		assert.Equal(t, tt.contentType, recorder.Header().Get("Content-Type"), tt.query)
+		assert.Equal(t, tt.expected, recorder.Body.String(), tt.query)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/render?from=sexpr&to=svg", strings.NewReader("(+ a b)")))
+	assert.Equal(t, "image/svg+xml", recorder.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(recorder.Body.String(), `<svg xmlns="http://www.w3.org/2000/svg" width="75.6" height="85"`), recorder.Body.String())
+
+	// The drawing formats follow the style and the orientation too.
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/render?from=sexpr&to=svg&style=unicode&orientation=up", strings.NewReader("(+ a b)")))
+	assert.Contains(t, recorder.Body.String(), `<text x="0" y="13" xml:space="preserve">a       b</text>`)
+	assert.Contains(t, recorder.Body.String(), `<text x="0" y="30" xml:space="preserve"> ╲     ╱</text>`)
+}
+
+func TestLimits(t *testing.T) {
+	l := limits{maxNodes: 7, maxDepth: 3, maxCells: 1000}
+	tests := []struct {
+		tree     string
+		expected string
+	}{
+		{"(a (b c d) (e f g))", ""},
+		{"(a (b c d) (e f (g h)))", "the tree has more than 7 nodes"},
+		{"(a (b (c d)))", "the tree is deeper than 3 levels"},
+		{"(a (b c) " + strings.Repeat("d", 60) + ")", "the drawing would take more than 1000 characters"},
+	}
+
+	for _, tt := range tests {
+		root, err := sexpr.Parse(tt.tree)
+		assert.NoError(t, err)
+		if err := l.check(root); tt.expected == "" {
+			assert.NoError(t, err, tt.tree)
+		} else {
+			assert.EqualError(t, err, tt.expected, tt.tree)
+		}
+	}
+
+	// The estimate is never below the real size of the drawing.
+	root, err := sexpr.Parse("(root (+ 1 (foo (+ 2 345) (bar 6789 9))) (+ 5432 5))")
+	assert.NoError(t, err)
+	rendering := printer.PrintTree(root)
+	exact := limits{maxNodes: 100, maxDepth: 100, maxCells: rendering.Width()*rendering.Height() - 1}
+	assert.Error(t, exact.check(root))
+}
+
+func TestServeErrors(t *testing.T) {
+	tests := []struct {
+		method string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{http.MethodGet, "/render?from=sexpr", "", http.StatusMethodNotAllowed, "method_not_allowed"},
+		{http.MethodPost, "/", "x", http.StatusNotFound, "not_found"},
+		{http.MethodPost, "/render", "x", http.StatusBadRequest, "invalid_parameter"},
+		{http.MethodPost, "/render?from=toml", "x", http.StatusBadRequest, "invalid_parameter"},
+		{http.MethodPost, "/render?from=sexpr&to=png", "x", http.StatusBadRequest, "invalid_parameter"},
+		{http.MethodPost, "/render?from=sexpr&style=bold", "x", http.StatusBadRequest, "invalid_parameter"},
+		{http.MethodPost, "/render?from=sexpr&width=wide", "x", http.StatusBadRequest, "invalid_parameter"},
+		{http.MethodPost, "/render?from=sexpr", "(a", http.StatusBadRequest, "invalid_input"},
+		{http.MethodPost, "/render?from=json", `{"value": ""}`, http.StatusBadRequest, "invalid_input"},
+		{http.MethodPost, "/render?from=sexpr", "(" + strings.Repeat("a ", 64) + ")", http.StatusRequestEntityTooLarge, "request_too_large"},
+		{http.MethodPost, "/render?from=sexpr&to=newick", "(a () b)", http.StatusUnprocessableEntity, "unsupported_tree"},
+		{http.MethodPost, "/render?from=sexpr", "(a (b c d) (e f g))", http.StatusUnprocessableEntity, "tree_too_large"},
+		{http.MethodPost, "/render?from=sexpr", "(a (b (c d)))", http.StatusUnprocessableEntity, "tree_too_large"},
+		{http.MethodPost, "/render?from=sexpr", "(a (" + strings.Repeat("b", 60) + " c))", http.StatusUnprocessableEntity, "tree_too_large"},
+	}
+
+	handler := newHandler(limits{maxBytes: 100, maxNodes: 5, maxDepth: 3, maxCells: 400})
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+		assert.Equal(t, tt.status, recorder.Code, tt.target)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"), tt.target)
+
+		var body apiError
+		if assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body), tt.target) {
+			assert.Equal(t, tt.code, body.Error.Code, tt.target)
+			assert.NotEmpty(t, body.Error.Message, tt.target)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/tmpltree"
+)
+
+// templateTree handles "template [FILE]". The template is named after the file, as template.ParseFiles does.
+func templateTree(args []string, stdin io.Reader) (*printer.Node, error) {
+	data, err := readInput(args, stdin)
+	if err != nil {
+		return nil, err
+	}
+	name := "stdin"
+	if len(args) == 1 && args[0] != "-" {
+		name = filepath.Base(args[0])
+	}
+	return tmpltree.Parse(name, string(data))
+}
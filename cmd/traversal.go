@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"unicode"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/traversal"
+)
+
+// traversalTree handles "traversal -in SEQ (-pre SEQ | -post SEQ)".
+func traversalTree(args []string) (*printer.Node, error) {
+	var pre, in, post string
+	flags := flag.NewFlagSet("traversal", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&pre, "pre", "", "")
+	flags.StringVar(&in, "in", "", "")
+	flags.StringVar(&post, "post", "", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return nil, errUsage
+	}
+
+	switch {
+	case in == "":
+		return nil, usageError("traversal: the inorder sequence (-in) is required")
+	case (pre == "") == (post == ""):
+		return nil, usageError("traversal: exactly one of the preorder (-pre) and postorder (-post) sequences is required")
+	case pre != "":
+		return traversal.FromPreIn(splitSequence(pre), splitSequence(in))
+	}
+	return traversal.FromPostIn(splitSequence(post), splitSequence(in))
+}
+
+// splitSequence splits a sequence of values separated by commas and/or whitespace.
+func splitSequence(seq string) []string {
+	return strings.FieldsFunc(seq, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// clearScreen moves the cursor to the top left corner of the terminal and clears the screen.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// defaultWatchInterval is how often the watched file is checked for changes.
+const defaultWatchInterval = 500 * time.Millisecond
+
+// watch renders the file, and renders it again every time its size or modification time changes, until the context is done.
+// The screen is cleared before every rendering. Errors, e.g. parse errors of a half-edited file, are printed in place of the tree,
+// and the watching goes on.
+func watch(ctx context.Context, path string, interval time.Duration, stdout io.Writer, render func() (string, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last fileState
+	first := true
+	for {
+		state := stat(path)
+		if first || state != last {
+			first, last = false, state
+
+			text, err := render()
+			if err != nil {
+				text = fmt.Sprintf("error: %v\n", err)
+			}
+			if _, err := io.WriteString(stdout, clearScreen+text); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// fileState is what's compared to detect changes of a file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+	err     string
+}
+
+func stat(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{err: err.Error()}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, since the output is checked while watch is writing it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// screens returns the texts written after every clearing of the screen.
+func (b *syncBuffer) screens() []string {
+	return strings.Split(b.String(), clearScreen)[1:]
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.sexp")
+	// Every change gets a later modification time, because the file system might not tell apart writes in quick succession.
+	// The file is replaced by a rename, so watch never sees the new content with the old time.
+	modTime := time.Now()
+	write := func(content string) {
+		tmp := path + ".tmp"
+		assert.NoError(t, os.WriteFile(tmp, []byte(content), 0o644))
+		modTime = modTime.Add(time.Second)
+		assert.NoError(t, os.Chtimes(tmp, modTime, modTime))
+		assert.NoError(t, os.Rename(tmp, path))
+	}
+	write("(a b c)")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var stdout syncBuffer
+	done := make(chan error)
+	go func() {
+		done <- watch(ctx, path, 5*time.Millisecond, &stdout, func() (string, error) {
+			root, err := fileTree([]string{path}, "", nil)
+			if err != nil {
+				return "", err
+			}
+			out := defaultOutput()
+			out.format = "sexpr"
+			return out.render(root, &stdout)
+		})
+	}()
+
+	waitForScreens := func(count int) {
+		assert.Eventually(t, func() bool { return len(stdout.screens()) >= count }, 5*time.Second, time.Millisecond)
+	}
+	waitForScreens(1)
+	write("(a (b c) d)")
+	waitForScreens(2)
+	write("(a (b")
+	waitForScreens(3)
+	assert.NoError(t, os.Remove(path))
+	waitForScreens(4)
+	write("(x y)")
+	waitForScreens(5)
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, []string{
+		"(a b c)\n",
+		"(a (b c) d)\n",
+		"error: 1:4: unclosed \"(\"\n",
+		"error: open " + path + ": no such file or directory\n",
+		"(x y)\n",
+	}, stdout.screens())
+}
+
+func TestRunWatchErrors(t *testing.T) {
+	tests := [][]string{
+		{"-watch"},
+		{"-watch", "-"},
+		{"-watch", "a.json", "b.json"},
+		{"-watch", "goexpr", "a"},
+		{"-watch", "-interval", "0s", "a.json"},
+	}
+
+	for _, args := range tests {
+		assert.EqualError(t, run(args, nil, &bytes.Buffer{}), "-watch requires a single input FILE and a positive -interval", args)
+	}
+}
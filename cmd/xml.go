@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/xmltree"
+)
+
+// xmlTree handles "xml [FLAGS] [FILE]".
+func xmlTree(args []string, stdin io.Reader) (*printer.Node, error) {
+	var opts xmltree.Options
+	var namespaces string
+	flags := flag.NewFlagSet("xml", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.BoolVar(&opts.Attributes, "attrs", false, "")
+	flags.IntVar(&opts.MaxDepth, "depth", 0, "")
+	flags.IntVar(&opts.MaxText, "text", 32, "")
+	flags.StringVar(&namespaces, "ns", xmltree.NamespacesHidden.String(), "")
+	if err := flags.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	var err error
+	if opts.Namespaces, err = xmltree.ParseNamespaces(namespaces); err != nil {
+		return nil, usageError(err.Error())
+	}
+
+	data, err := readInput(flags.Args(), stdin)
+	if err != nil {
+		return nil, err
+	}
+	return xmltree.Parse(bytes.NewReader(data), opts)
+}
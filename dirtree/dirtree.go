@@ -0,0 +1,165 @@
+// Package dirtree reads directory hierarchies from a file system (fs.FS) and shows them as trees.
+//
+// Directories usually have more than two entries, so the hierarchy is drawn as an n-ary tree with printer.DrawNary(),
+// which shows all the entries of a directory directly below it. The outline style lists one entry per line instead,
+// indented by two spaces per level. Entries that can't be read are shown with their errors, and the rest is read as usual.
+package dirtree
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Options controls which entries are read and how they are labelled.
+type Options struct {
+	// MaxDepth limits the levels of entries below the root directory. Zero means no limit.
+	MaxDepth int
+	// Include lists glob patterns (see path.Match) of file names. If it's not empty, only the matching files are read. Directories are always read.
+	Include []string
+	// Exclude lists glob patterns of the names of files and directories that are skipped.
+	Exclude []string
+	// Hidden includes the entries with names starting with a dot.
+	Hidden bool
+	// Sizes adds sizes to the labels: the size of a file, or the total size of the files read from a directory.
+	// The sizes of the directories at MaxDepth include the entries below it, which are read but not shown.
+	Sizes bool
+}
+
+// Entry is a file or a directory.
+type Entry struct {
+	Name     string
+	IsDir    bool
+	Size     int64 // the size of a file, or the total size of the files read from a directory
+	Children []*Entry
+	Err      error // the error of reading the file info or the directory, if any
+}
+
+// Read reads the directory hierarchy from the root directory of the file system (e.g. os.DirFS(dir) or fstest.MapFS).
+// The name of the returned root entry is rootName, which also prefixes the error when the root directory can't be read.
+func Read(fsys fs.FS, rootName string, opts Options) (*Entry, error) {
+	for _, pattern := range append(append([]string{}, opts.Include...), opts.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	root := &Entry{Name: rootName, IsDir: true}
+	readDir(fsys, ".", root, 1, &opts)
+	if root.Err != nil {
+		return nil, fmt.Errorf("%s: %w", rootName, root.Err)
+	}
+	return root, nil
+}
+
+// readDir reads the entries of the directory, at the given depth, into the parent. An error is stored in the entry that caused it.
+// Entries deeper than MaxDepth are not added to the parent, but with Sizes they're still read to count the size of the parent.
+func readDir(fsys fs.FS, dir string, parent *Entry, depth int, opts *Options) {
+	shown := opts.MaxDepth == 0 || depth <= opts.MaxDepth
+	if !shown && !opts.Sizes {
+		return
+	}
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		parent.Err = err
+		return
+	}
+	for _, de := range entries {
+		name := de.Name()
+		if !opts.Hidden && strings.HasPrefix(name, ".") || matchesAny(opts.Exclude, name) {
+			continue
+		}
+		if !de.IsDir() && len(opts.Include) > 0 && !matchesAny(opts.Include, name) {
+			continue
+		}
+
+		entry := &Entry{Name: name, IsDir: de.IsDir()}
+		if entry.IsDir {
+			readDir(fsys, path.Join(dir, name), entry, depth+1, opts)
+		} else if info, err := de.Info(); err != nil {
+			entry.Err = err
+		} else {
+			entry.Size = info.Size()
+		}
+		parent.Size += entry.Size
+		if shown {
+			parent.Children = append(parent.Children, entry)
+		}
+	}
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the label of the entry: the name, followed by a slash for a directory, optionally by the size, and by the error if there's one.
+func (e *Entry) Label(sizes bool) string {
+	label := e.Name
+	if e.IsDir && !strings.HasSuffix(label, "/") {
+		label += "/"
+	}
+	if sizes {
+		label += " (" + FormatSize(e.Size) + ")"
+	}
+	if e.Err != nil {
+		err := e.Err
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			// The path is already in the tree.
+			err = pathErr.Err
+		}
+		label += " [error: " + err.Error() + "]"
+	}
+	return label
+}
+
+// Nary returns the n-ary tree of the entry and its children, to be drawn with printer.DrawNary().
+func (e *Entry) Nary(sizes bool) *printer.NaryNode {
+	node := &printer.NaryNode{Value: e.Label(sizes)}
+	for _, child := range e.Children {
+		node.Children = append(node.Children, child.Nary(sizes))
+	}
+	return node
+}
+
+// Outline returns the entry and its children one per line, indented by two spaces per level.
+func (e *Entry) Outline(sizes bool) string {
+	var sb strings.Builder
+	e.outline(&sb, "", sizes)
+	return sb.String()
+}
+
+func (e *Entry) outline(sb *strings.Builder, indent string, sizes bool) {
+	sb.WriteString(indent + e.Label(sizes) + "\n")
+	for _, child := range e.Children {
+		child.outline(sb, indent+"  ", sizes)
+	}
+}
+
+// FormatSize returns the size in bytes in a short, human readable form, e.g. "512B", "1.5K", "20M".
+func FormatSize(size int64) string {
+	const units = "KMGTPE"
+	if size < 1024 {
+		return fmt.Sprintf("%dB", size)
+	}
+	value := float64(size)
+	unit := -1
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if value < 10 {
+		return fmt.Sprintf("%.1f%c", value, units[unit])
+	}
+	return fmt.Sprintf("%.0f%c", value, units[unit])
+}
@@ -0,0 +1,143 @@
+package dirtree
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"README.md":          {Data: []byte(strings.Repeat("r", 100))},
+	".git/HEAD":          {Data: []byte("ref")},
+	"cmd/main.go":        {Data: []byte(strings.Repeat("m", 2000))},
+	"cmd/main_test.go":   {Data: []byte(strings.Repeat("t", 48))},
+	"internal/render/a":  {Data: []byte("a")},
+	"internal/render/b":  {Data: []byte("b")},
+	"internal/render/c":  {Data: []byte("c")},
+	"internal/empty.txt": {Data: nil},
+}
+
+// shape returns a compact, parenthesized description of the hierarchy, with the labels without sizes.
+func shape(e *Entry) string {
+	if len(e.Children) == 0 {
+		return e.Label(false)
+	}
+	parts := []string{e.Label(false)}
+	for _, child := range e.Children {
+		parts = append(parts, shape(child))
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		opts     Options
+		expected string
+	}{
+		{Options{}, "(root/ README.md (cmd/ main.go main_test.go) (internal/ empty.txt (render/ a b c)))"},
+		{Options{Hidden: true}, "(root/ (.git/ HEAD) README.md (cmd/ main.go main_test.go) (internal/ empty.txt (render/ a b c)))"},
+		{Options{MaxDepth: 1}, "(root/ README.md cmd/ internal/)"},
+		{Options{MaxDepth: 2}, "(root/ README.md (cmd/ main.go main_test.go) (internal/ empty.txt render/))"},
+		{Options{MaxDepth: 1, Sizes: true}, "(root/ README.md cmd/ internal/)"},
+		{Options{Include: []string{"*.go"}}, "(root/ (cmd/ main.go main_test.go) (internal/ render/))"},
+		{Options{Exclude: []string{"*_test.go", "render"}}, "(root/ README.md (cmd/ main.go) (internal/ empty.txt))"},
+	}
+
+	for _, tt := range tests {
+		root, err := Read(testFS, "root", tt.opts)
+		if assert.NoError(t, err, tt.opts) {
+			assert.Equal(t, tt.expected, shape(root), tt.opts)
+		}
+	}
+
+	_, err := Read(testFS, "root", Options{Include: []string{"["}})
+	assert.EqualError(t, err, `invalid pattern "[": syntax error in pattern`)
+
+	_, err = Read(testFS, "root", Options{Exclude: []string{"a\\"}})
+	assert.Error(t, err)
+}
+
+func TestOutlineWithSizes(t *testing.T) {
+	root, err := Read(testFS, "root", Options{MaxDepth: 2, Sizes: true})
+	assert.NoError(t, err)
+
+	// The sizes of the directories at the maximal depth include the files below it.
+	expected := render.Nlnl(`
+root/ (2.1K)
+  README.md (100B)
+  cmd/ (2.0K)
+    main.go (2.0K)
+    main_test.go (48B)
+  internal/ (3B)
+    empty.txt (0B)
+    render/ (3B)
+`)
+	assert.Equal(t, expected, root.Outline(true))
+}
+
+func TestNaryRendering(t *testing.T) {
+	root, err := Read(testFS, "root", Options{Include: []string{"main.go", "*.md"}})
+	assert.NoError(t, err)
+
+	expected := render.Nlnl(`
+             root/
+      _________|_________
+     /         |         \
+README.md     cmd/    internal/
+               |          |
+               |          |
+            main.go    render/
+`)
+	assert.Equal(t, expected, strings.Join(printer.DrawNary(root.Nary(false), printer.StyleASCII), "\n")+"\n")
+}
+
+// brokenFS is a file system with a directory that can't be read.
+type brokenFS struct {
+	fstest.MapFS
+	broken string
+}
+
+func (b brokenFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name == b.broken {
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: fs.ErrPermission}
+	}
+	return b.MapFS.ReadDir(name)
+}
+
+func TestReadErrors(t *testing.T) {
+	root, err := Read(brokenFS{MapFS: testFS, broken: "cmd"}, "root", Options{Sizes: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(root/ README.md cmd/ [error: permission denied] (internal/ empty.txt (render/ a b c)))", shape(root))
+		assert.Equal(t, int64(103), root.Size)
+	}
+
+	_, err = Read(brokenFS{MapFS: testFS, broken: "."}, "root", Options{})
+	assert.ErrorIs(t, err, fs.ErrPermission)
+	assert.EqualError(t, err, "root: readdirent .: permission denied")
+	_, err = Read(testFS, "root", Options{})
+	assert.NoError(t, err)
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1024, "1.0K"},
+		{1536, "1.5K"},
+		{10 * 1024, "10K"},
+		{5 * 1024 * 1024, "5.0M"},
+		{3 << 40, "3.0T"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, FormatSize(tt.size))
+	}
+}
@@ -0,0 +1,204 @@
+// Package document reads printer.Node trees from JSON and YAML documents.
+//
+// A node is an object with the value and up to two children, which are nodes themselves (see the printer.Node documentation):
+//
+//	{"value": "+", "left": {"value": "1"}, "right": {"value": "2"}}
+//
+// The names of the fields can be changed with Fields, so existing documents can be read as they are.
+// Other fields are ignored. Values that are numbers or booleans are converted to strings; YAML values keep
+// the text they have in the document, so 0x10, 2.50 and 2001-12-14 are printed as they are written.
+// A missing child may be left out, or set to null.
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"gopkg.in/yaml.v3"
+)
+
+// Fields holds the names of the fields of a node object.
+type Fields struct {
+	Value string
+	Left  string
+	Right string
+}
+
+// DefaultFields are the field names used by the json and yaml tags of printer.Node.
+var DefaultFields = Fields{Value: "value", Left: "left", Right: "right"}
+
+// withDefaults returns the fields with empty names replaced by the default ones.
+func (f Fields) withDefaults() Fields {
+	if f.Value == "" {
+		f.Value = DefaultFields.Value
+	}
+	if f.Left == "" {
+		f.Left = DefaultFields.Left
+	}
+	if f.Right == "" {
+		f.Right = DefaultFields.Right
+	}
+	return f
+}
+
+// ParseJSON reads a tree from a JSON document. Empty field names are replaced by the DefaultFields.
+func ParseJSON(data []byte, fields Fields) (*printer.Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, jsonError(data, err)
+	}
+	end := dec.InputOffset()
+	if _, err := dec.Token(); err != io.EOF {
+		end += int64(len(data[end:]) - len(bytes.TrimLeft(data[end:], " \t\r\n")))
+		return nil, fmt.Errorf("%s: unexpected data after the document", positionAt(data, end))
+	}
+	return build(doc, "$", fields.withDefaults())
+}
+
+// ParseYAML reads a tree from a YAML document. Empty field names are replaced by the DefaultFields.
+func ParseYAML(data []byte, fields Fields) (*printer.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return build(fromYAML(&doc), "$", fields.withDefaults())
+}
+
+// literal is a YAML scalar that is not a string, e.g. a number, a boolean or a date.
+// It keeps the text from the document, so 0x10 and 2.50 are not turned into 16 and 2.5.
+type literal string
+
+// fromYAML converts a YAML node into the values build expects. Scalars keep their source text.
+func fromYAML(n *yaml.Node) any {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return fromYAML(n.Content[0])
+	case yaml.AliasNode:
+		return fromYAML(n.Alias)
+	case yaml.MappingNode:
+		obj := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			obj[n.Content[i].Value] = fromYAML(n.Content[i+1])
+		}
+		return obj
+	case yaml.SequenceNode:
+		list := make([]any, len(n.Content))
+		for i, item := range n.Content {
+			list[i] = fromYAML(item)
+		}
+		return list
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!null":
+			return nil
+		case "!!str", "!!binary":
+			return n.Value
+		}
+		return literal(n.Value)
+	}
+	return nil
+}
+
+// jsonError adds the line and the column to JSON syntax and type errors, which only know the byte offset.
+func jsonError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%s: %w", positionAt(data, syntaxErr.Offset), err)
+	}
+	if errors.Is(err, io.EOF) {
+		return errors.New("empty document")
+	}
+	return err
+}
+
+// positionAt returns the "line:column" of the byte offset in data. Both the line and the column start at 1.
+func positionAt(data []byte, offset int64) string {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Sprintf("%d:%d", line, column)
+}
+
+// build converts a decoded node object into a printer.Node. The path describes the position of the object in the document, e.g. "$.left.right".
+func build(doc any, path string, fields Fields) (*printer.Node, error) {
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected an object, found %s", path, describe(doc))
+	}
+
+	rawValue, ok := obj[fields.Value]
+	if !ok {
+		return nil, fmt.Errorf("%s: missing %q field (fields found: %s)", path, fields.Value, strings.Join(keys(obj), ", "))
+	}
+	value, err := scalar(rawValue)
+	if err != nil {
+		return nil, fmt.Errorf("%s.%s: %w", path, fields.Value, err)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%s.%s: empty value", path, fields.Value)
+	}
+
+	node := &printer.Node{Value: value}
+	if child := obj[fields.Left]; child != nil {
+		if node.LeftChild, err = build(child, path+"."+fields.Left, fields); err != nil {
+			return nil, err
+		}
+	}
+	if child := obj[fields.Right]; child != nil {
+		if node.RightChild, err = build(child, path+"."+fields.Right, fields); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// scalar converts a string, number or boolean to the node value.
+func scalar(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case literal:
+		return string(v), nil
+	case json.Number, int, int64, uint64, float64, bool:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("expected a string, a number or a boolean, found %s", describe(v))
+}
+
+func describe(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func keys(obj map[string]any) []string {
+	result := make([]string, 0, len(obj))
+	for k := range obj {
+		result = append(result, fmt.Sprintf("%q", k))
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,110 @@
+package document
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		fields   Fields
+		expected string
+	}{
+		{`{"value": "x"}`, Fields{}, "x"},
+		{`{"value": "+", "left": {"value": 1}, "right": {"value": 2.50}}`, Fields{}, "(+ 1 2.50)"},
+		{`{"value": "-", "left": {"value": "x"}, "right": null, "comment": "ignored"}`, Fields{}, "(- x)"},
+		{`{"value": "neg", "right": {"value": true}}`, Fields{}, "(neg () true)"},
+		{`{"op": "*", "lhs": {"op": "a"}, "rhs": {"op": "b"}}`, Fields{Value: "op", Left: "lhs", Right: "rhs"}, "(* a b)"},
+		{`{"name": "*", "left": {"name": "a"}}`, Fields{Value: "name"}, "(* a)"},
+	}
+
+	for _, tt := range tests {
+		actual, err := ParseJSON([]byte(tt.input), tt.fields)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.input)
+		}
+	}
+}
+
+func TestParseJSONErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{``, `empty document`},
+		{"{\n  \"value\": \"+\",\n  \"left\": {\"value\" 1}\n}", `3:21: invalid character '1' after object key`},
+		{`{"value": "x"} {}`, `1:16: unexpected data after the document`},
+		{`[]`, `$: expected an object, found an array`},
+		{`{"val": "+", "left": {}}`, `$: missing "value" field (fields found: "left", "val")`},
+		{`{"value": "+", "left": {"value": "1"}, "right": {"value": {}}}`, `$.right.value: expected a string, a number or a boolean, found an object`},
+		{`{"value": "+", "left": "1"}`, `$.left: expected an object, found a string`},
+		{`{"value": ""}`, `$.value: empty value`},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseJSON([]byte(tt.input), Fields{})
+		assert.EqualError(t, err, tt.expected, tt.input)
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	input := `
+value: +
+left:
+  value: 1
+right:
+  value: "*"
+  left: {value: x}
+  right: {value: 2.5}
+`
+	actual, err := ParseYAML([]byte(input), Fields{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(+ 1 (* x 2.5))", sexpr.Format(actual))
+	}
+
+	actual, err = ParseYAML([]byte("label: x\nkids: {label: y}\n"), Fields{Value: "label", Left: "kids"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(x y)", sexpr.Format(actual))
+	}
+
+	// Scalars keep the text from the document.
+	actual, err = ParseYAML([]byte("value: 2001-12-14\nleft: {value: 0x10}\nright: {value: 2.50, left: {value: yes}}\n"), Fields{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(2001-12-14 0x10 (2.50 yes))", sexpr.Format(actual))
+	}
+
+	actual, err = ParseYAML([]byte("value: +\nleft: &x {value: a}\nright: *x\n"), Fields{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(+ a a)", sexpr.Format(actual))
+	}
+
+	_, err = ParseYAML([]byte("value: [\n"), Fields{})
+	assert.ErrorContains(t, err, "line 1")
+
+	_, err = ParseYAML([]byte("value: x\nleft: [1]\n"), Fields{})
+	assert.EqualError(t, err, "$.left: expected an object, found an array")
+
+	_, err = ParseYAML([]byte("value: x\nleft: 1\n"), Fields{})
+	assert.EqualError(t, err, "$.left: expected an object, found 1")
+
+	_, err = ParseYAML(nil, Fields{})
+	assert.EqualError(t, err, "$: expected an object, found null")
+}
+
+// The tags of printer.Node and DefaultFields describe the same schema.
+func TestNodeTagsMatchDefaultFields(t *testing.T) {
+	tree, err := sexpr.Parse("(+ (- x) (neg () 2))")
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(tree)
+	assert.NoError(t, err)
+
+	parsed, err := ParseJSON(data, DefaultFields)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tree, parsed)
+	}
+}
@@ -0,0 +1,123 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Labels of the nodes that represent objects and arrays in the structure of a document.
+const (
+	ObjectLabel = "{}"
+	ArrayLabel  = "[]"
+)
+
+// TruncationMark is appended to strings shortened because of StructureOptions.MaxString.
+const TruncationMark = "..."
+
+// StructureOptions controls how Structure shows a document.
+type StructureOptions struct {
+	// MaxString is the maximum number of characters of a string (both values and keys) shown in a node.
+	// Longer strings are cut and end with the TruncationMark. Zero means no limit.
+	MaxString int
+}
+
+// Structure shows an arbitrary JSON document as a tree, unlike ParseJSON, which expects the document to describe the nodes.
+//
+// Objects and arrays become nodes labelled with ObjectLabel and ArrayLabel, and their members become the children, in the order of the document.
+// Every child is labelled with its key or index, e.g. `name: "Alice"`, `tags: []` or `[0]: 42`.
+// Scalars are leaves showing the JSON text of the value; strings are quoted.
+// Containers with more than two members are folded with printer.Group().
+//
+// Characters outside of ASCII are escaped, so they don't break the alignment of the drawing.
+func Structure(data []byte, opts StructureOptions) (*printer.Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	s := structure{dec: dec, opts: opts}
+	root, err := s.value("")
+	if err != nil {
+		return nil, jsonError(data, err)
+	}
+	end := dec.InputOffset()
+	if _, err := dec.Token(); err != io.EOF {
+		end += int64(len(data[end:]) - len(bytes.TrimLeft(data[end:], " \t\r\n")))
+		return nil, fmt.Errorf("%s: unexpected data after the document", positionAt(data, end))
+	}
+	return root, nil
+}
+
+type structure struct {
+	dec  *json.Decoder
+	opts StructureOptions
+}
+
+// value reads the next value from the decoder and returns its node. The prefix is the key or the index of the value, e.g. "name: ".
+func (s *structure) value(prefix string) (*printer.Node, error) {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		var children []*printer.Node
+		for i := 0; s.dec.More(); i++ {
+			childPrefix := fmt.Sprintf("[%d]: ", i)
+			if tok == '{' {
+				key, err := s.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				childPrefix = s.key(key.(string)) + ": "
+			}
+			child, err := s.value(childPrefix)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		// The closing delimiter.
+		if _, err := s.dec.Token(); err != nil {
+			return nil, err
+		}
+
+		label := ArrayLabel
+		if tok == '{' {
+			label = ObjectLabel
+		}
+		return printer.Group(prefix+label, children...), nil
+	case string:
+		return &printer.Node{Value: prefix + strconv.QuoteToASCII(s.truncate(tok))}, nil
+	case nil:
+		return &printer.Node{Value: prefix + "null"}, nil
+	default:
+		// json.Number or bool.
+		return &printer.Node{Value: prefix + fmt.Sprint(tok)}, nil
+	}
+}
+
+// key returns the key as it is shown in the labels. It's quoted only if needed, i.e. when it's empty or contains anything but printable ASCII.
+func (s *structure) key(key string) string {
+	key = s.truncate(key)
+	if key == "" || strings.ContainsFunc(key, func(r rune) bool { return r < ' ' || r > '~' }) {
+		return strconv.QuoteToASCII(key)
+	}
+	return key
+}
+
+func (s *structure) truncate(str string) string {
+	if s.opts.MaxString <= 0 {
+		return str
+	}
+	runes := []rune(str)
+	if len(runes) <= s.opts.MaxString {
+		return str
+	}
+	return string(runes[:s.opts.MaxString]) + TruncationMark
+}
@@ -0,0 +1,51 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructure(t *testing.T) {
+	tests := []struct {
+		input     string
+		maxString int
+		expected  string
+	}{
+		{`42`, 0, "42"},
+		{`"x"`, 0, `"\"x\""`},
+		{`{}`, 0, "{}"},
+		{`[]`, 0, "[]"},
+		{`{"a": 1, "b": [true, null]}`, 0, `({} "a: 1" ("b: []" "[0]: true" "[1]: null"))`},
+		{`{"z": 1, "a": 2, "m": 3}`, 0, `({} (... "z: 1" "a: 2") "m: 3")`},
+		{`[1.50, -2e3]`, 0, `([] "[0]: 1.50" "[1]: -2e3")`},
+		{`{"": "", "kéy": "Zürich"}`, 0, `({} "\"\": \"\"" "\"k\\u00e9y\": \"Z\\u00fcrich\"")`},
+		{`{"description": "a long text"}`, 6, `({} "descri...: \"a long...\"")`},
+		{`{"abc": "abc"}`, 3, `({} "abc: \"abc\"")`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Structure([]byte(tt.input), StructureOptions{MaxString: tt.maxString})
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.input)
+		}
+	}
+}
+
+func TestStructureErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{``, `empty document`},
+		{`[1,`, `1:4: unexpected end of JSON input`},
+		{"{\n  \"a\" 1}", `2:8: invalid character '1' after object key`},
+		{`{} []`, `1:4: unexpected data after the document`},
+	}
+
+	for _, tt := range tests {
+		_, err := Structure([]byte(tt.input), StructureOptions{})
+		assert.EqualError(t, err, tt.expected, tt.input)
+	}
+}
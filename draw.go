@@ -0,0 +1,162 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+)
+
+// Style holds the strings that replace the connector characters of PrintTree() output.
+// Every string must be a single character, one column wide, or the connectors won't meet the values.
+type Style struct {
+	Left       string // replaces "/"
+	Right      string // replaces "\"
+	Horizontal string // replaces "_"
+	Vertical   string // replaces "|", used only by DrawNary()
+}
+
+// Styles of the connectors.
+var (
+	StyleASCII   = Style{Left: "/", Right: "\\", Horizontal: "_", Vertical: "|"}
+	StyleUnicode = Style{Left: "╱", Right: "╲", Horizontal: "─", Vertical: "│"}
+)
+
+// Orientation selects where the root of a drawing is.
+type Orientation int
+
+const (
+	// TopDown draws the root at the top and the children below it, as PrintTree() does.
+	TopDown Orientation = iota
+	// BottomUp draws the root at the bottom and the children above it, like a real tree.
+	BottomUp
+)
+
+// DrawOptions controls the appearance of Draw() output. The zero value draws the same picture as PrintTree().
+type DrawOptions struct {
+	Style       Style // the zero value means StyleASCII
+	Orientation Orientation
+}
+
+// Line is a line of a drawing.
+type Line struct {
+	Text string
+	// Connectors tells whether the line holds connectors (otherwise it holds values), e.g. to print them in different colors.
+	Connectors bool
+}
+
+// Draw renders the tree like PrintTree() and returns the lines of the picture, from the top, with the connectors in the given style and orientation.
+//
+// Every level of the tree takes a line of values followed by exactly three lines of connectors (Fig. 3 in printer.go),
+// so the connector characters are replaced only in the connector lines, and values that contain them stay intact.
+func Draw(root *Node, opts DrawOptions) []Line {
+	var lines []Line
+	DrawEach(root, opts, func(line Line) error {
+		lines = append(lines, line)
+		return nil
+	})
+	return lines
+}
+
+// DrawEach draws the tree like Draw(), but passes the lines to yield one by one instead of returning them,
+// so only a single line of a very wide tree is built at a time. The first error returned by yield stops the drawing and is returned.
+func DrawEach(root *Node, opts DrawOptions, yield func(Line) error) error {
+	rendering := PrintTree(root)
+
+	style := opts.Style
+	if style == (Style{}) {
+		style = StyleASCII
+	}
+	if opts.Orientation == BottomUp {
+		// Upside down, a connector going down to the left goes up to the right.
+		style.Left, style.Right = style.Right, style.Left
+	}
+	replacer := strings.NewReplacer("/", style.Left, "\\", style.Right, "_", style.Horizontal)
+
+	height := rendering.Height()
+	for i := 0; i < height; i++ {
+		row := i
+		if opts.Orientation == BottomUp {
+			row = height - 1 - i
+		}
+		line := Line{Text: rendering.Line(row), Connectors: row%4 != 0}
+		if opts.Orientation == BottomUp {
+			line.Text = liftHorizontal(rendering, row)
+		}
+		if line.Connectors {
+			line.Text = replacer.Replace(line.Text)
+		}
+		if err := yield(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// liftHorizontal returns the row of the rendering with the horizontal connectors moved for the BottomUp orientation.
+// The underscores of the middle connector row are drawn at the bottom of their cells, where they meet the slashes of the row below.
+// Upside down, that row is above them, so they are moved up to the lower connector row, which becomes the row above.
+// The lower connector row has only spaces between its slashes, so the underscores don't cover anything.
+func liftHorizontal(rendering *render.Rendering, row int) string {
+	text := rendering.Line(row)
+	switch row % 4 {
+	case 2:
+		return strings.TrimRight(strings.ReplaceAll(text, "_", " "), " ")
+	case 3:
+		middle := rendering.Line(row - 1)
+		if !strings.Contains(middle, "_") {
+			return text
+		}
+		lifted := []byte(text)
+		for i := 0; i < len(middle); i++ {
+			if middle[i] != '_' {
+				continue
+			}
+			for len(lifted) <= i {
+				lifted = append(lifted, ' ')
+			}
+			lifted[i] = '_'
+		}
+		return string(lifted)
+	}
+	return text
+}
+
+// Placement is the position of a node value in a drawing. Both the row and the column start at 0.
+type Placement struct {
+	Node   *Node
+	Row    int
+	Column int
+}
+
+// Place returns the positions of the node values in the lines returned by Draw() with the given options, in level order.
+// The values of a level are drawn in one line, from left to right, in the same order. The style doesn't change the positions.
+func Place(root *Node, opts DrawOptions) []Placement {
+	rendering := PrintTree(root)
+	height := rendering.Height()
+
+	var result []Placement
+	level := []*Node{root}
+	for row := 0; len(level) > 0; row += 4 {
+		text := rendering.Line(row)
+		drawnRow := row
+		if opts.Orientation == BottomUp {
+			drawnRow = height - 1 - row
+		}
+		column := 0
+		var next []*Node
+		for _, n := range level {
+			// Only spaces are drawn between the values.
+			column += strings.Index(text[column:], n.Value)
+			result = append(result, Placement{Node: n, Row: drawnRow, Column: column})
+			column += len(n.Value)
+
+			for _, child := range []*Node{n.LeftChild, n.RightChild} {
+				if child != nil {
+					next = append(next, child)
+				}
+			}
+		}
+		level = next
+	}
+	return result
+}
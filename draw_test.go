@@ -0,0 +1,158 @@
+package printer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func drawingText(lines []Line) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(line.Text + "\n")
+	}
+	return sb.String()
+}
+
+func TestDrawDefaultsMatchPrintTree(t *testing.T) {
+	root := Group("root", &Node{Value: "a/b"}, &Node{Value: "c_d"}, &Node{Value: `e\f`})
+
+	lines := Draw(root, DrawOptions{})
+	assert.Equal(t, PrintTree(root).String(), drawingText(lines))
+	for i, line := range lines {
+		assert.Equal(t, i%4 != 0, line.Connectors, line.Text)
+	}
+}
+
+func TestDrawStyleKeepsValues(t *testing.T) {
+	root := &Node{Value: "/_\\", LeftChild: Group("a/b", &Node{Value: "x_y"}, &Node{Value: "z"}), RightChild: Group(`c\d`, &Node{Value: "1"}, &Node{Value: "2"})}
+
+	actual := drawingText(Draw(root, DrawOptions{Style: Style{Left: "L", Right: "R", Horizontal: "-"}}))
+	expected := render.Nlnl(`
+             /_\
+            L   R
+          -L     R-
+         L         R
+      a/b           c\d
+     L   R         L   R
+    L     R       L     R
+   L       R     L       R
+x_y         z   1         2
+`)
+	assert.Equal(t, expected, actual)
+}
+
+func TestDrawBottomUp(t *testing.T) {
+	root := &Node{Value: "root", LeftChild: &Node{Value: "a"}, RightChild: Group("b", &Node{Value: "c"}, &Node{Value: "d"})}
+
+	lines := Draw(root, DrawOptions{Orientation: BottomUp})
+	expected := render.Nlnl(`
+       c       d
+        \     /
+         \   /
+          \ /
+a          b
+ \        /
+  \      /
+   \    /
+    root
+`)
+	assert.Equal(t, expected, drawingText(lines))
+	assert.False(t, lines[0].Connectors)
+	assert.True(t, lines[1].Connectors)
+	assert.False(t, lines[len(lines)-1].Connectors)
+}
+
+func TestDrawBottomUpMovesHorizontalConnectors(t *testing.T) {
+	foo := &Node{Value: "foo", LeftChild: Group("+", &Node{Value: "2"}, &Node{Value: "345"}), RightChild: Group("bar", &Node{Value: "6789"}, &Node{Value: "9"})}
+	root := &Node{Value: "root", LeftChild: &Node{Value: "+", LeftChild: &Node{Value: "1"}, RightChild: foo}, RightChild: Group("+", &Node{Value: "5432"}, &Node{Value: "5"})}
+
+	actual := drawingText(Draw(root, DrawOptions{Orientation: BottomUp}))
+	expected := render.Nlnl(`
+2       345    6789         9
+ \     /           \       /
+  \   /             \     /
+   \ /               \   /
+    +                 bar
+     \____       ____/
+          \     /
+           \   /
+    1       foo   5432       5
+     \     /          \     /
+      \   /            \   /
+       \ /              \ /
+        +                +
+         \___        ___/
+             \      /
+              \    /
+               root
+`)
+	assert.Equal(t, expected, actual)
+
+	// The connectors are the same as in the TopDown drawing, only the underscores are one row lower (before turning the picture).
+	unicode := drawingText(Draw(root, DrawOptions{Style: StyleUnicode, Orientation: BottomUp}))
+	assert.Equal(t, strings.NewReplacer("\\", "╲", "/", "╱", "_", "─").Replace(expected), unicode)
+}
+
+func TestDrawUnicodeBottomUp(t *testing.T) {
+	root := &Node{Value: "+", LeftChild: &Node{Value: "1"}, RightChild: &Node{Value: "2"}}
+
+	actual := drawingText(Draw(root, DrawOptions{Style: StyleUnicode, Orientation: BottomUp}))
+	expected := render.Nlnl(`
+1       2
+ ╲     ╱
+  ╲   ╱
+   ╲ ╱
+    +
+`)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPlace(t *testing.T) {
+	a, b, c := &Node{Value: "a b"}, &Node{Value: "b"}, &Node{Value: "c"}
+	root := &Node{Value: "b", LeftChild: &Node{Value: "x", LeftChild: a}, RightChild: &Node{Value: "y", LeftChild: b, RightChild: c}}
+
+	lines := Draw(root, DrawOptions{})
+	placements := Place(root, DrawOptions{})
+	assert.Len(t, placements, 6)
+	for _, p := range placements {
+		text := lines[p.Row].Text
+		assert.Equal(t, p.Node.Value, text[p.Column:p.Column+len(p.Node.Value)])
+	}
+	assert.Equal(t, []*Node{root, root.LeftChild, root.RightChild, a, b, c}, []*Node{
+		placements[0].Node, placements[1].Node, placements[2].Node, placements[3].Node, placements[4].Node, placements[5].Node,
+	})
+	assert.Equal(t, Placement{Node: a, Row: 8, Column: 0}, placements[3])
+}
+
+func TestDrawEachStopsOnError(t *testing.T) {
+	root := &Node{Value: "+", LeftChild: &Node{Value: "1"}, RightChild: &Node{Value: "2"}}
+	errStop := errors.New("stop")
+
+	var lines []Line
+	err := DrawEach(root, DrawOptions{}, func(line Line) error {
+		lines = append(lines, line)
+		if len(lines) == 2 {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, Draw(root, DrawOptions{})[:2], lines)
+}
+
+func TestPlaceBottomUp(t *testing.T) {
+	root := &Node{Value: "+", LeftChild: &Node{Value: "a", LeftChild: &Node{Value: "x"}}, RightChild: &Node{Value: "b"}}
+
+	opts := DrawOptions{Style: StyleUnicode, Orientation: BottomUp}
+	lines := Draw(root, opts)
+	placements := Place(root, opts)
+	assert.Equal(t, Placement{Node: root, Row: 8, Column: 8}, placements[0])
+	assert.Equal(t, Placement{Node: root.LeftChild.LeftChild, Row: 0, Column: 0}, placements[3])
+	for _, p := range placements {
+		assert.Equal(t, p.Node.Value, lines[p.Row].Text[p.Column:p.Column+len(p.Node.Value)])
+	}
+}
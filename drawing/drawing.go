@@ -0,0 +1,223 @@
+// Package drawing parses tree drawings made by printer.PrintTree() back into printer.Node trees.
+//
+// Parsing starts with the root, which is the only value in the first non-empty line, and follows the connectors down to the children:
+// a "/" diagonally below-left of a value leads to the left child, a "\" diagonally below-right leads to the right child.
+// Every connector spans three rows, and its middle row may be extended with underscores (see the figures in printer.go).
+// Values in the same row must be separated by at least three spaces, so a value can't contain three spaces in a row itself.
+// Every character of the drawing must be a part of a value or a connector, anything else is reported as an error.
+//
+// The columns of the drawing are counted in bytes, because the printer measures the values in bytes: a value with multi-byte
+// characters is drawn wider than it looks, and the connectors are aligned with its bytes, not with its characters.
+package drawing
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/syntax"
+)
+
+// valueGap is the smallest number of spaces between two values in the same row.
+const valueGap = 3
+
+// Position is a location in the parsed drawing. Both the line and the column start at 1, the column counts runes, not bytes.
+type Position = syntax.Position
+
+// SyntaxError describes a problem with the parsed drawing and its position.
+type SyntaxError = syntax.Error
+
+// Parse parses a tree drawing. Empty lines before and after the drawing are skipped. Errors are of type *SyntaxError.
+func Parse(text string) (*printer.Node, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	c := &canvas{}
+	for i, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if col := strings.IndexRune(line, '\t'); col >= 0 {
+			return nil, &SyntaxError{Pos: Position{Line: i + 1, Column: len([]rune(line[:col])) + 1}, Msg: "tabs are not supported, use spaces"}
+		}
+		if line == "" && len(c.rows) == 0 {
+			c.firstLine++
+			continue
+		}
+		c.rows = append(c.rows, []byte(line))
+	}
+	for len(c.rows) > 0 && len(c.rows[len(c.rows)-1]) == 0 {
+		c.rows = c.rows[:len(c.rows)-1]
+	}
+	if len(c.rows) == 0 {
+		return nil, &SyntaxError{Pos: Position{Line: 1, Column: 1}, Msg: "empty drawing"}
+	}
+
+	c.used = make([][]bool, len(c.rows))
+	for i, row := range c.rows {
+		c.used[i] = make([]bool, len(row))
+	}
+
+	start, end := c.trimmed(0)
+	if strings.Contains(string(c.rows[0][start:end+1]), strings.Repeat(" ", valueGap)) {
+		return nil, c.errorAt(0, start, "the first line must contain only the root value")
+	}
+
+	root, err := c.parseNode(0, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkAllUsed(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// canvas holds the rows of the drawing and marks the bytes that are a part of the tree.
+type canvas struct {
+	rows      [][]byte
+	used      [][]bool
+	firstLine int // the number of skipped empty lines before the first row
+}
+
+// errorAt returns the error at the byte column of the row, reported at the column in runes. The row may be below the drawing.
+func (c *canvas) errorAt(row, col int, msg string) error {
+	var line []byte
+	if row < len(c.rows) {
+		line = c.rows[row]
+	}
+	column := utf8.RuneCount(line[:min(col, len(line))]) + max(col-len(line), 0) + 1
+	return &SyntaxError{Pos: Position{Line: c.firstLine + row + 1, Column: column}, Msg: msg}
+}
+
+func (c *canvas) at(row, col int) byte {
+	if row < 0 || row >= len(c.rows) || col < 0 || col >= len(c.rows[row]) {
+		return ' '
+	}
+	return c.rows[row][col]
+}
+
+// expect marks the character as used, or returns an error if it's not the expected one.
+func (c *canvas) expect(row, col int, expected byte, what string) error {
+	if col < 0 || c.at(row, col) != expected {
+		return c.errorAt(row, max(col, 0), fmt.Sprintf("expected %q of %s", expected, what))
+	}
+	c.used[row][col] = true
+	return nil
+}
+
+// trimmed returns the columns of the first and the last non-space character of the row.
+func (c *canvas) trimmed(row int) (int, int) {
+	line := c.rows[row]
+	start := 0
+	for start < len(line) && line[start] == ' ' {
+		start++
+	}
+	return start, len(line) - 1
+}
+
+// isGap reports whether the column starts a gap between values (or the end of the row).
+func (c *canvas) isGap(row, col int) bool {
+	for i := 0; i < valueGap; i++ {
+		if c.at(row, col+i) != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNode parses the value in the given row and columns, and its children.
+func (c *canvas) parseNode(row, start, end int) (*printer.Node, error) {
+	for col := start; col <= end; col++ {
+		c.used[row][col] = true
+	}
+	node := &printer.Node{Value: string(c.rows[row][start : end+1])}
+
+	var err error
+	if c.at(row+1, start-1) == '/' {
+		if node.LeftChild, err = c.parseLeftChild(row, start, node.Value); err != nil {
+			return nil, err
+		}
+	}
+	if c.at(row+1, end+1) == '\\' {
+		if node.RightChild, err = c.parseRightChild(row, end, node.Value); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// parseLeftChild follows the "/" connector from the first column of the parent value to the left child.
+func (c *canvas) parseLeftChild(row, start int, parent string) (*printer.Node, error) {
+	what := fmt.Sprintf("the connector to the left child of %q", parent)
+	col := start - 1
+	if err := c.expect(row+1, col, '/', what); err != nil {
+		return nil, err
+	}
+	col--
+	if err := c.expect(row+2, col, '/', what); err != nil {
+		return nil, err
+	}
+	col--
+	for c.at(row+2, col) == '_' {
+		c.used[row+2][col] = true
+		col--
+	}
+	if err := c.expect(row+3, col, '/', what); err != nil {
+		return nil, err
+	}
+
+	// The value of the left child ends right before the end of the connector.
+	end := col - 1
+	if c.at(row+4, end) == ' ' {
+		return nil, c.errorAt(row+4, max(end, 0), fmt.Sprintf("missing the left child of %q", parent))
+	}
+	start = end
+	for start > 0 && !c.isGap(row+4, start-valueGap) {
+		start--
+	}
+	return c.parseNode(row+4, start, end)
+}
+
+// parseRightChild follows the "\" connector from the last column of the parent value to the right child.
+func (c *canvas) parseRightChild(row, end int, parent string) (*printer.Node, error) {
+	what := fmt.Sprintf("the connector to the right child of %q", parent)
+	col := end + 1
+	if err := c.expect(row+1, col, '\\', what); err != nil {
+		return nil, err
+	}
+	col++
+	if err := c.expect(row+2, col, '\\', what); err != nil {
+		return nil, err
+	}
+	col++
+	for c.at(row+2, col) == '_' {
+		c.used[row+2][col] = true
+		col++
+	}
+	if err := c.expect(row+3, col, '\\', what); err != nil {
+		return nil, err
+	}
+
+	// The value of the right child starts right after the end of the connector.
+	start := col + 1
+	if c.at(row+4, start) == ' ' {
+		return nil, c.errorAt(row+4, start, fmt.Sprintf("missing the right child of %q", parent))
+	}
+	end = start
+	for end+1 < len(c.rows[row+4]) && !c.isGap(row+4, end+1) {
+		end++
+	}
+	return c.parseNode(row+4, start, end)
+}
+
+// checkAllUsed returns an error for the first character that is not a part of the tree.
+func (c *canvas) checkAllUsed() error {
+	for row, line := range c.rows {
+		for col, b := range line {
+			if b != ' ' && !c.used[row][col] {
+				r, _ := utf8.DecodeRune(line[col:])
+				return c.errorAt(row, col, fmt.Sprintf("%q is not connected to the tree", r))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package drawing
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	input := render.Nlnl(`
+               root
+              /    \
+          ___/      \___
+         /              \
+        +                +
+       / \              / \
+      /   \            /   \
+     /     \          /     \
+    1       foo   5432       5
+           /   \
+      ____/     \____
+     /               \
+    +                 bar
+   / \               /   \
+  /   \             /     \
+ /     \           /       \
+2       345    6789         9
+`)
+
+	actual, err := Parse(input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(root (+ 1 (foo (+ 2 345) (bar 6789 9))) (+ 5432 5))", sexpr.Format(actual))
+	}
+}
+
+// Drawings made by PrintTree are parsed back into the same trees.
+func TestParsePrintedTrees(t *testing.T) {
+	trees := []string{
+		"x",
+		"(+ 1 2)",
+		`("a b" "c d" "e  f")`,
+		"(- (* x y))",
+		"(neg () (* x y))",
+		"(root (a () b) (c d))",
+		"(4444 (1 333 1) (4444 1 22))",
+		"(* (+ 1 (foo (+ 2 345) (bar 6789 9))) (+ 432 5))",
+		"(/ (/ (/ (/ a b) c) d) (^ e (^ f (^ g h))))",
+		// Multi-byte values are laid out by their bytes, and parsed the same way.
+		"(żółw (α β) γ)",
+		"(é (ñ x) ü)",
+	}
+
+	for _, tree := range trees {
+		root, err := sexpr.Parse(tree)
+		assert.NoError(t, err)
+
+		drawing := printer.PrintTree(root).String()
+		actual, err := Parse("\n\n" + drawing + "\n")
+		if assert.NoError(t, err, drawing) {
+			assert.Equal(t, root, actual, drawing)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"\n  \n", `1:1: empty drawing`},
+		{"a   b", `1:1: the first line must contain only the root value`},
+		{"\n\t+", `2:1: tabs are not supported, use spaces`},
+		{render.Nlnl(`
+    +
+   / \
+  /   |
+ /     \
+1       2
+`), `3:7: expected '\\' of the connector to the right child of "+"`},
+		{render.Nlnl(`
+    +
+   / \
+  /   \
+ /     \
+1
+`), `5:9: missing the right child of "+"`},
+		{render.Nlnl(`
+    +
+   /
+  /
+ /
+`), `5:1: missing the left child of "+"`},
+		{render.Nlnl(`
+    +
+   / \
+  /   \
+ /     \
+1       2
+          \
+`), `6:11: '\\' is not connected to the tree`},
+		{render.Nlnl(`
+    +
+     \
+      \
+       \
+        2   x
+`), `5:13: 'x' is not connected to the tree`},
+		// The columns of the errors count runes, though the drawing is laid out by bytes.
+		{render.Nlnl(`
++
+ \
+  \
+   \
+    ż   é
+`), `5:9: 'é' is not connected to the tree`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if assert.ErrorAs(t, err, &syntaxErr, tt.input) {
+			assert.Equal(t, tt.expected, err.Error(), tt.input)
+		}
+	}
+}
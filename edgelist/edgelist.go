@@ -0,0 +1,256 @@
+// Package edgelist builds printer.Node trees from edge lists in CSV or TSV, where every row describes a single node:
+//
+//	id,parent,side,label
+//	1,,,+
+//	2,1,L,1
+//	3,1,R,*
+//
+// The root is the only node with an empty parent. The side is "L" or "R" (or "left" and "right", in any case).
+// Children with an empty side take the free sides of their parent in the order of the rows, left first.
+// The label is optional: a node without a label shows its id. Lines starting with "#" are skipped, and so is the header row.
+package edgelist
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Options controls the parsing of the edge list.
+type Options struct {
+	// Comma is the field delimiter. If it's zero, the delimiter is a tab if the first line contains one, and a comma otherwise.
+	Comma rune
+}
+
+// Problem is a single problem found in the edge list.
+type Problem struct {
+	Line int // 0 if the problem is not related to a single line
+	Msg  string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Msg
+	}
+	return fmt.Sprintf("line %d: %s", p.Line, p.Msg)
+}
+
+// Error is returned when the edge list doesn't describe a single tree. It lists all the problems found.
+type Error struct {
+	Problems []Problem
+}
+
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// row is a single node of the edge list.
+type row struct {
+	line   int
+	id     string
+	parent string
+	side   string // "L", "R" or ""
+	node   *printer.Node
+}
+
+// Parse reads the edge list and builds its tree. If the rows don't describe a single binary tree, the error is an *Error listing every problem:
+// repeated ids, unknown sides, multiple roots, parents that are not defined (orphans), cycles and more than one child on a side.
+func Parse(r io.Reader, opts Options) (*printer.Node, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, problems, err := readRows(data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]*row{}
+	var valid []*row
+	for _, row := range rows {
+		if first, ok := byID[row.id]; ok {
+			problems = append(problems, Problem{row.line, fmt.Sprintf("node %q is already defined on line %d", row.id, first.line)})
+			continue
+		}
+		byID[row.id] = row
+		valid = append(valid, row)
+	}
+
+	var root *row
+	// broken holds the rows with problems that also disconnect them from the root.
+	broken := map[*row]bool{}
+	for _, row := range valid {
+		switch parent, ok := byID[row.parent]; {
+		case row.parent == "":
+			if root != nil {
+				problems = append(problems, Problem{row.line, fmt.Sprintf("node %q is another root, the root is %q on line %d", row.id, root.id, root.line)})
+				broken[row] = true
+				continue
+			}
+			if row.side != "" {
+				problems = append(problems, Problem{row.line, fmt.Sprintf("root %q can't have a side", row.id)})
+			}
+			root = row
+		case !ok:
+			problems = append(problems, Problem{row.line, fmt.Sprintf("parent %q of node %q is not defined", row.parent, row.id)})
+			broken[row] = true
+		case inCycle(row, byID):
+			problems = append(problems, Problem{row.line, fmt.Sprintf("node %q is a part of a cycle", row.id)})
+			broken[row] = true
+		default:
+			if msg := attach(parent, row); msg != "" {
+				problems = append(problems, Problem{row.line, msg})
+				broken[row] = true
+			}
+		}
+	}
+
+	// Descendants of the broken rows are not connected to the root either, but only the first broken ancestor is reported.
+	for _, row := range valid {
+		if broken[row] || row == root {
+			continue
+		}
+		for ancestor := byID[row.parent]; ancestor != nil && ancestor != row; ancestor = byID[ancestor.parent] {
+			if broken[ancestor] {
+				problems = append(problems, Problem{row.line, fmt.Sprintf("node %q is not connected to the root, because of node %q on line %d", row.id, ancestor.id, ancestor.line)})
+				break
+			}
+		}
+	}
+
+	if root == nil && len(valid) > 0 {
+		problems = append(problems, Problem{0, "no root: every node has a parent"})
+	}
+	if len(valid) == 0 && len(problems) == 0 {
+		problems = append(problems, Problem{0, "no nodes"})
+	}
+	if len(problems) > 0 {
+		sort.SliceStable(problems, func(i, j int) bool {
+			return problems[j].Line == 0 && problems[i].Line != 0 || problems[i].Line != 0 && problems[i].Line < problems[j].Line
+		})
+		return nil, &Error{Problems: problems}
+	}
+	return root.node, nil
+}
+
+// inCycle reports whether following the parents from the row leads back to it.
+func inCycle(start *row, byID map[string]*row) bool {
+	seen := map[*row]bool{}
+	for r := start; r != nil; r = byID[r.parent] {
+		if seen[r] {
+			return r == start
+		}
+		seen[r] = true
+	}
+	return false
+}
+
+// attach adds the child to its parent. Returns the description of the problem if the side is already taken.
+func attach(parent, child *row) string {
+	side := child.side
+	if side == "" {
+		side = "L"
+		if parent.node.LeftChild != nil {
+			side = "R"
+		}
+	}
+
+	slot, name := &parent.node.LeftChild, "left"
+	if side == "R" {
+		slot, name = &parent.node.RightChild, "right"
+	}
+	if *slot != nil {
+		return fmt.Sprintf("node %q already has the %s child %q", parent.id, name, (*slot).Value)
+	}
+	*slot = child.node
+	return ""
+}
+
+// readRows reads the rows of the edge list. Problems with single rows are returned as problems, only errors of the CSV syntax are returned as errors.
+func readRows(data []byte, opts Options) ([]*row, []Problem, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = opts.Comma
+	if reader.Comma == 0 {
+		reader.Comma = detectComma(data)
+	}
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	// In a TSV the leading space includes the tabs, so trimming it would remove empty fields. All the fields are trimmed below anyway.
+	reader.TrimLeadingSpace = reader.Comma != '\t'
+
+	var rows []*row
+	var problems []Problem
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if len(rows) == 0 && len(problems) == 0 && isHeader(record) {
+			continue
+		}
+		if len(record) < 3 || len(record) > 4 {
+			problems = append(problems, Problem{line, fmt.Sprintf("expected 3 or 4 fields (id, parent, side, label), found %d", len(record))})
+			continue
+		}
+
+		r := &row{line: line, id: record[0], parent: record[1]}
+		if r.id == "" {
+			problems = append(problems, Problem{line, "empty id"})
+			continue
+		}
+		switch strings.ToUpper(record[2]) {
+		case "":
+		case "L", "LEFT":
+			r.side = "L"
+		case "R", "RIGHT":
+			r.side = "R"
+		default:
+			problems = append(problems, Problem{line, fmt.Sprintf("unknown side %q of node %q, expected L or R", record[2], r.id)})
+			continue
+		}
+
+		label := r.id
+		if len(record) == 4 && record[3] != "" {
+			label = record[3]
+		}
+		r.node = &printer.Node{Value: label}
+		rows = append(rows, r)
+	}
+	return rows, problems, nil
+}
+
+func detectComma(data []byte) rune {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 || line[0] == '#' {
+			continue
+		}
+		if bytes.ContainsRune(line, '\t') {
+			return '\t'
+		}
+		break
+	}
+	return ','
+}
+
+func isHeader(record []string) bool {
+	return len(record) >= 3 && strings.EqualFold(record[0], "id") && strings.EqualFold(record[1], "parent") && strings.EqualFold(record[2], "side")
+}
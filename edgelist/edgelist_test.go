@@ -0,0 +1,65 @@
+package edgelist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		opts     Options
+		expected string
+	}{
+		{"1,,,x\n", Options{}, "x"},
+		{"id,parent,side,label\n1,,,+\n2,1,L,1\n3,1,R,*\n4,3,,2\n5,3,,3\n", Options{}, "(+ 1 (* 2 3))"},
+		{"# exported tree\nroot,,\nb,root,right\na,root,left,\n", Options{}, "(root a b)"},
+		{"1\t\t\tneg\n2\t1\tR\tx\n", Options{}, "(neg () x)"},
+		{"1;;;\"a;b\"\n2;1;l;c\n", Options{Comma: ';'}, `("a;b" c)`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(strings.NewReader(tt.input), tt.opts)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.input)
+		}
+	}
+}
+
+func TestParseProblems(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "no nodes"},
+		{"1,2,,a\n2,1,,b\n", "line 1: node \"1\" is a part of a cycle\nline 2: node \"2\" is a part of a cycle\nno root: every node has a parent"},
+		{
+			"1,,,root\n2,,,other\n3,2,L,a\n",
+			"line 2: node \"2\" is another root, the root is \"1\" on line 1\nline 3: node \"3\" is not connected to the root, because of node \"2\" on line 2",
+		},
+		{
+			"1,,,root\n2,9,L,a\n3,2,L,b\n4,3,R,c\n",
+			"line 2: parent \"9\" of node \"2\" is not defined\nline 3: node \"3\" is not connected to the root, because of node \"2\" on line 2\nline 4: node \"4\" is not connected to the root, because of node \"2\" on line 2",
+		},
+		{"1,,,root\n2,1,L,a\n3,1,L,b\n4,1,,c\n5,1,,d\n", "line 3: node \"1\" already has the left child \"a\"\nline 5: node \"1\" already has the right child \"c\""},
+		{"1,,,root\n1,,,again\n", "line 2: node \"1\" is already defined on line 1"},
+		{"1,,L,root\n2,1,up\n3\n,1,R\n", "line 1: root \"1\" can't have a side\nline 2: unknown side \"up\" of node \"2\", expected L or R\nline 3: expected 3 or 4 fields (id, parent, side, label), found 1\nline 4: empty id"},
+		{"1,,,root\n2,2,L,self\n", "line 2: node \"2\" is a part of a cycle"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.input), Options{})
+		var listErr *Error
+		if assert.ErrorAs(t, err, &listErr, tt.input) {
+			assert.Equal(t, tt.expected, err.Error(), tt.input)
+		}
+	}
+}
+
+func TestParseCSVSyntaxError(t *testing.T) {
+	_, err := Parse(strings.NewReader("1,,,\"unterminated\n"), Options{})
+	assert.ErrorContains(t, err, "line 1")
+}
@@ -0,0 +1,315 @@
+// Package explorer is an interactive terminal viewer of printer.Node trees, meant for trees too big to read in one picture.
+//
+// The tree is drawn by the printer and shown through a viewport that follows the selected node.
+// Subtrees can be collapsed and expanded, which draws the tree again, and node values can be searched.
+// The logic is independent of the terminal: Explorer turns keys into views, and Run connects it to a Terminal,
+// e.g. the Linux terminal returned by OpenTTY or a fake one in tests.
+package explorer
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// CollapsedMark is appended to the values of collapsed nodes.
+const CollapsedMark = " [+]"
+
+// help is shown in the status line when there's nothing else to show.
+const help = "arrows/hjkl: move  enter/space: collapse/expand  /: search  n: next match  pgup/pgdn: scroll  q: quit"
+
+// selectionPlaceholder fills the value of the selected node when the tree is drawn, so that the node can be found in the drawing.
+// It's a control character, which doesn't occur in values that can be printed.
+const selectionPlaceholder = "\x00"
+
+// Explorer holds the state of the viewer: the collapsed nodes, the selected node, the search and the position of the viewport.
+type Explorer struct {
+	root      *printer.Node
+	parents   map[*printer.Node]*printer.Node
+	collapsed map[*printer.Node]bool
+	selected  *printer.Node
+
+	searching bool   // whether the search query is being typed
+	query     string // the last search query
+	message   string // shown in the status line until the next key
+
+	top, left int  // the first row and column of the drawing shown in the viewport
+	follow    bool // whether the viewport has to be moved to show the selected node
+}
+
+// New returns an explorer of the tree with the root selected. Nodes deeper than expandDepth levels below the root are collapsed;
+// zero expands the whole tree.
+func New(root *printer.Node, expandDepth int) *Explorer {
+	e := &Explorer{
+		root:      root,
+		parents:   map[*printer.Node]*printer.Node{},
+		collapsed: map[*printer.Node]bool{},
+		selected:  root,
+		follow:    true,
+	}
+	var walk func(n *printer.Node, depth int)
+	walk = func(n *printer.Node, depth int) {
+		if expandDepth > 0 && depth >= expandDepth && !n.IsLeaf() {
+			e.collapsed[n] = true
+		}
+		for _, child := range []*printer.Node{n.LeftChild, n.RightChild} {
+			if child != nil {
+				e.parents[child] = n
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(root, 0)
+	return e
+}
+
+// Selected returns the selected node.
+func (e *Explorer) Selected() *printer.Node {
+	return e.selected
+}
+
+// HandleKey updates the state after a key press. Returns false when the user wants to quit.
+func (e *Explorer) HandleKey(key Key, viewHeight int) bool {
+	e.message = ""
+	if e.searching {
+		e.handleSearchKey(key)
+		return true
+	}
+
+	switch {
+	case key.Code == KeyInterrupt, key.Code == KeyRune && key.Rune == 'q':
+		return false
+	case key.Code == KeyUp, key.Code == KeyRune && key.Rune == 'k':
+		if parent := e.parents[e.selected]; parent != nil {
+			e.selectNode(parent)
+		}
+	case key.Code == KeyDown, key.Code == KeyRune && key.Rune == 'j':
+		if children := e.visibleChildren(e.selected); len(children) > 0 {
+			e.selectNode(children[0])
+		}
+	case key.Code == KeyLeft, key.Code == KeyRune && key.Rune == 'h':
+		e.selectSibling(-1)
+	case key.Code == KeyRight, key.Code == KeyRune && key.Rune == 'l':
+		e.selectSibling(1)
+	case key.Code == KeyEnter, key.Code == KeyRune && key.Rune == ' ':
+		if !e.selected.IsLeaf() {
+			e.collapsed[e.selected] = !e.collapsed[e.selected]
+			e.follow = true
+		}
+	case key.Code == KeyRune && key.Rune == '/':
+		e.searching, e.query = true, ""
+	case key.Code == KeyRune && key.Rune == 'n':
+		e.search()
+	case key.Code == KeyPageUp:
+		e.top -= max(viewHeight-1, 1)
+	case key.Code == KeyPageDown:
+		e.top += max(viewHeight-1, 1)
+	}
+	return true
+}
+
+func (e *Explorer) handleSearchKey(key Key) {
+	switch key.Code {
+	case KeyRune:
+		e.query += string(key.Rune)
+	case KeyBackspace:
+		if e.query != "" {
+			runes := []rune(e.query)
+			e.query = string(runes[:len(runes)-1])
+		}
+	case KeyEnter:
+		e.searching = false
+		e.search()
+	case KeyEscape, KeyInterrupt:
+		e.searching = false
+	}
+}
+
+func (e *Explorer) selectNode(n *printer.Node) {
+	e.selected = n
+	e.follow = true
+}
+
+// visibleChildren returns the children of the node shown in the drawing.
+func (e *Explorer) visibleChildren(n *printer.Node) []*printer.Node {
+	if e.collapsed[n] {
+		return nil
+	}
+	var children []*printer.Node
+	for _, child := range []*printer.Node{n.LeftChild, n.RightChild} {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// selectSibling moves the selection to the previous (-1) or the next (1) visible node on the same level.
+// The nodes of a level are drawn from left to right in preorder.
+func (e *Explorer) selectSibling(direction int) {
+	level := []*printer.Node{e.root}
+	for len(level) > 0 {
+		for i, n := range level {
+			if n == e.selected {
+				if j := i + direction; j >= 0 && j < len(level) {
+					e.selectNode(level[j])
+				}
+				return
+			}
+		}
+		var next []*printer.Node
+		for _, n := range level {
+			next = append(next, e.visibleChildren(n)...)
+		}
+		level = next
+	}
+}
+
+// search selects the next node after the selected one (in preorder, wrapping around) whose value contains the query,
+// ignoring case. Collapsed ancestors of the found node are expanded.
+func (e *Explorer) search() {
+	if e.query == "" {
+		return
+	}
+	var nodes []*printer.Node
+	var walk func(n *printer.Node)
+	walk = func(n *printer.Node) {
+		if n == nil {
+			return
+		}
+		nodes = append(nodes, n)
+		walk(n.LeftChild)
+		walk(n.RightChild)
+	}
+	walk(e.root)
+
+	start := 0
+	for i, n := range nodes {
+		if n == e.selected {
+			start = i + 1
+		}
+	}
+	query := strings.ToLower(e.query)
+	for i := range nodes {
+		n := nodes[(start+i)%len(nodes)]
+		if strings.Contains(strings.ToLower(n.Value), query) {
+			for p := e.parents[n]; p != nil; p = e.parents[p] {
+				delete(e.collapsed, p)
+			}
+			e.selectNode(n)
+			return
+		}
+	}
+	e.message = fmt.Sprintf("no match for %q", e.query)
+}
+
+// displayTree returns a copy of the tree as it is drawn: without the children of collapsed nodes,
+// and with the value of the selected node replaced by placeholders.
+func (e *Explorer) displayTree(n *printer.Node) *printer.Node {
+	if n == nil {
+		return nil
+	}
+	value := n.Value
+	copied := &printer.Node{}
+	if e.collapsed[n] {
+		value += CollapsedMark
+	} else {
+		copied.LeftChild = e.displayTree(n.LeftChild)
+		copied.RightChild = e.displayTree(n.RightChild)
+	}
+	if n == e.selected {
+		value = strings.Repeat(selectionPlaceholder, len(value))
+	}
+	copied.Value = value
+	return copied
+}
+
+// selectedValue returns the value of the selected node as it is drawn.
+func (e *Explorer) selectedValue() string {
+	if e.collapsed[e.selected] {
+		return e.selected.Value + CollapsedMark
+	}
+	return e.selected.Value
+}
+
+// View draws the tree and returns the lines of the screen: the viewport, followed by the status line.
+// The selected node is shown in reverse video.
+func (e *Explorer) View(width, height int) []string {
+	viewHeight := max(height-1, 1)
+	var rows []string
+	for _, line := range printer.Draw(e.displayTree(e.root), printer.DrawOptions{}) {
+		rows = append(rows, line.Text)
+	}
+
+	// Find the selected node and put its value back. Columns count runes from here on, the drawing counts bytes.
+	selRow, selCol := 0, 0
+	selected := e.selectedValue()
+	for i, row := range rows {
+		if col := strings.Index(row, selectionPlaceholder); col >= 0 {
+			rows[i] = row[:col] + selected + row[col+len(selected):]
+			selRow, selCol = i, utf8.RuneCountInString(row[:col])
+			break
+		}
+	}
+	selEnd := selCol + utf8.RuneCountInString(selected)
+
+	if e.follow {
+		e.follow = false
+		if selRow < e.top {
+			e.top = selRow
+		} else if selRow >= e.top+viewHeight {
+			e.top = selRow - viewHeight + 1
+		}
+		if selCol < e.left || selEnd-selCol > width {
+			e.left = selCol
+		} else if selEnd > e.left+width {
+			e.left = selEnd - width
+		}
+	}
+	e.top = max(min(e.top, len(rows)-viewHeight), 0)
+
+	lines := make([]string, 0, height)
+	for i := e.top; i < e.top+viewHeight; i++ {
+		if i >= len(rows) {
+			lines = append(lines, "")
+			continue
+		}
+		line := crop(rows[i], e.left, width)
+		if i == selRow {
+			// Highlight the visible part of the selected value.
+			runes := []rune(line)
+			from, to := max(selCol-e.left, 0), min(selEnd-e.left, len(runes))
+			if from < to {
+				line = string(runes[:from]) + "\x1b[7m" + string(runes[from:to]) + "\x1b[0m" + string(runes[to:])
+			}
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, crop(e.status(), 0, width))
+}
+
+// status returns the status line: the search prompt, a message, or the help.
+func (e *Explorer) status() string {
+	switch {
+	case e.searching:
+		return "/" + e.query
+	case e.message != "":
+		return e.message
+	}
+	return help
+}
+
+// crop returns the columns of the line from left, at most width of them. A column is a rune.
+func crop(line string, left, width int) string {
+	runes := []rune(line)
+	if left >= len(runes) {
+		return ""
+	}
+	runes = runes[left:]
+	if len(runes) > width {
+		runes = runes[:width]
+	}
+	return string(runes)
+}
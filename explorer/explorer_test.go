@@ -0,0 +1,204 @@
+package explorer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+// plain shows the highlighted text in angle brackets instead of the escape sequences.
+func plain(lines []string) string {
+	text := strings.Join(lines, "\n") + "\n"
+	return strings.NewReplacer("\x1b[7m", "<", "\x1b[0m", ">").Replace(text)
+}
+
+func keys(runes string) []Key {
+	var result []Key
+	for _, r := range runes {
+		result = append(result, Key{Code: KeyRune, Rune: r})
+	}
+	return result
+}
+
+func newExplorer(t *testing.T, tree string, expandDepth int) *Explorer {
+	root, err := sexpr.Parse(tree)
+	assert.NoError(t, err)
+	return New(root, expandDepth)
+}
+
+func TestNavigation(t *testing.T) {
+	e := newExplorer(t, "(root (a (b c d) e) (f g (h i j)))", 0)
+
+	tests := []struct {
+		key      Key
+		expected string
+	}{
+		{Key{Code: KeyUp}, "root"},
+		{Key{Code: KeyDown}, "a"},
+		{Key{Code: KeyLeft}, "a"},
+		{Key{Code: KeyRight}, "f"},
+		{Key{Code: KeyDown}, "g"},
+		{Key{Code: KeyLeft}, "e"},
+		{Key{Code: KeyLeft}, "b"},
+		{Key{Code: KeyRune, Rune: 'j'}, "c"},
+		{Key{Code: KeyRune, Rune: 'l'}, "d"},
+		{Key{Code: KeyRune, Rune: 'l'}, "i"},
+		{Key{Code: KeyRune, Rune: 'k'}, "h"},
+		{Key{Code: KeyRune, Rune: 'h'}, "g"},
+	}
+
+	for i, tt := range tests {
+		assert.True(t, e.HandleKey(tt.key, 10))
+		assert.Equal(t, tt.expected, e.Selected().Value, "step %d", i)
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	e := newExplorer(t, "(root (a b c) d)", 0)
+	e.HandleKey(Key{Code: KeyDown}, 10)
+	e.HandleKey(Key{Code: KeyEnter}, 10)
+
+	expected := `
+        root
+       /    \
+      /      \
+     /        \
+<a [+]>          d
+` + help + "\n"
+	assert.Equal(t, expected[1:], plain(e.View(120, 6)))
+
+	// The children of a collapsed node can't be selected.
+	e.HandleKey(Key{Code: KeyDown}, 10)
+	assert.Equal(t, "a", e.Selected().Value)
+
+	e.HandleKey(Key{Code: KeyRune, Rune: ' '}, 10)
+	e.HandleKey(Key{Code: KeyDown}, 10)
+	assert.Equal(t, "b", e.Selected().Value)
+
+	// Leaves can't be collapsed.
+	e.HandleKey(Key{Code: KeyEnter}, 10)
+	assert.NotContains(t, plain(e.View(120, 10)), CollapsedMark)
+}
+
+func TestExpandDepth(t *testing.T) {
+	e := newExplorer(t, "(root (a (b c d) e) f)", 1)
+
+	expected := `
+        <root>
+       /    \
+      /      \
+     /        \
+a [+]          f
+` + help + "\n"
+	assert.Equal(t, expected[1:], plain(e.View(120, 6)))
+}
+
+func TestSearch(t *testing.T) {
+	e := newExplorer(t, "(root (alpha (Beta x y) z) (beta2 w))", 1)
+
+	for _, key := range append(append(keys("/bet"), Key{Code: KeyRune, Rune: 'x'}, Key{Code: KeyBackspace}), Key{Code: KeyEnter}) {
+		e.HandleKey(key, 10)
+	}
+	// The first match is inside a collapsed subtree, which is expanded.
+	assert.Equal(t, "Beta", e.Selected().Value)
+	assert.Contains(t, plain(e.View(120, 20)), "alpha ")
+	assert.Contains(t, plain(e.View(120, 20)), "<Beta [+]>")
+
+	e.HandleKey(Key{Code: KeyRune, Rune: 'n'}, 10)
+	assert.Equal(t, "beta2", e.Selected().Value)
+	e.HandleKey(Key{Code: KeyRune, Rune: 'n'}, 10)
+	assert.Equal(t, "Beta", e.Selected().Value)
+
+	for _, key := range append(keys("/nothing"), Key{Code: KeyEnter}) {
+		e.HandleKey(key, 10)
+	}
+	assert.Equal(t, "Beta", e.Selected().Value)
+	lines := e.View(120, 20)
+	assert.Equal(t, `no match for "nothing"`, lines[len(lines)-1])
+
+	// Escape cancels the search, and the prompt is shown while typing.
+	for _, key := range keys("/x") {
+		e.HandleKey(key, 10)
+	}
+	lines = e.View(120, 20)
+	assert.Equal(t, "/x", lines[len(lines)-1])
+	e.HandleKey(Key{Code: KeyEscape}, 10)
+	lines = e.View(120, 20)
+	assert.Equal(t, help, lines[len(lines)-1])
+	assert.Equal(t, "Beta", e.Selected().Value)
+}
+
+func TestViewportFollowsSelection(t *testing.T) {
+	e := newExplorer(t, "(root (a (b (c (d leaf-number-one leaf-number-two) x) y) z) w)", 0)
+	for i := 0; i < 5; i++ {
+		e.HandleKey(Key{Code: KeyDown}, 4)
+	}
+	assert.Equal(t, "leaf-number-one", e.Selected().Value)
+
+	lines := e.View(12, 5)
+	assert.Len(t, lines, 5)
+	// The value is wider than the viewport, so it's shown from its beginning.
+	assert.Equal(t, "<leaf-number->", strings.TrimSpace(plain(lines[3:4])))
+	assert.Equal(t, help[:12], lines[4])
+
+	// Scrolling moves the viewport away from the selection, until the selection changes.
+	e.HandleKey(Key{Code: KeyPageUp}, 4)
+	assert.NotContains(t, plain(e.View(12, 5)), "<")
+	e.HandleKey(Key{Code: KeyRight}, 4)
+	assert.Equal(t, "leaf-number-two", e.Selected().Value)
+	assert.Contains(t, plain(e.View(12, 5)), "<leaf-number->")
+}
+
+// fakeTerminal replays the keys and records the screens.
+type fakeTerminal struct {
+	*KeyReader
+	bytes.Buffer
+	width, height int
+}
+
+func (ft *fakeTerminal) Size() (int, int, error) {
+	return ft.width, ft.height, nil
+}
+
+func TestRun(t *testing.T) {
+	root, err := sexpr.Parse("(+ 1 2)")
+	assert.NoError(t, err)
+
+	term := &fakeTerminal{KeyReader: NewKeyReader(strings.NewReader("\x1b[Bq")), width: 20, height: 6}
+	assert.NoError(t, Run(term, root, 0))
+
+	output := term.String()
+	assert.True(t, strings.HasPrefix(output, enterScreen))
+	assert.True(t, strings.HasSuffix(output, leaveScreen))
+	screens := strings.Split(strings.TrimSuffix(strings.TrimPrefix(output, enterScreen), leaveScreen), home)[1:]
+	if assert.Len(t, screens, 2) {
+		assert.True(t, strings.HasPrefix(screens[0], "    \x1b[7m+\x1b[0m"+clearLine+"\r\n"), screens[0])
+		assert.Contains(t, screens[1], "\x1b[7m1\x1b[0m")
+	}
+
+	// Running out of keys ends the explorer too.
+	term = &fakeTerminal{KeyReader: NewKeyReader(strings.NewReader("")), width: 20, height: 6}
+	assert.NoError(t, Run(term, root, 0))
+}
+
+func TestViewCountsRunes(t *testing.T) {
+	e := newExplorer(t, "(ąęćńółśźż)", 0)
+	lines := e.View(7, 2)
+	// The value is cropped at the seventh letter, not at the seventh byte.
+	assert.Equal(t, "<ąęćńółś>\n"+help[:7]+"\n", plain(lines))
+	for _, line := range lines {
+		assert.True(t, utf8.ValidString(line), line)
+	}
+
+	e = newExplorer(t, "(ą ęć óś)", 0)
+	e.HandleKey(Key{Code: KeyDown}, 4)
+	e.HandleKey(Key{Code: KeyRight}, 4)
+	assert.Equal(t, "óś", e.Selected().Value)
+	lines = e.View(20, 5)
+	assert.Equal(t, "ęć", strings.Fields(plain(lines[3:4]))[0])
+	assert.Equal(t, "<óś>", strings.Fields(plain(lines[3:4]))[1])
+}
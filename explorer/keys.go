@@ -0,0 +1,119 @@
+package explorer
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// KeyCode identifies a key. Printable characters are all KeyRune, with the character in Key.Rune.
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyEnter
+	KeyBackspace
+	KeyEscape
+	KeyInterrupt // Ctrl+C, which doesn't raise a signal in raw mode
+	KeyUnknown
+)
+
+// Key is a key pressed by the user.
+type Key struct {
+	Code KeyCode
+	Rune rune // the character of a KeyRune
+}
+
+// KeyReader decodes the keys from the bytes sent by a terminal in raw mode: characters, control keys and VT100 escape sequences.
+type KeyReader struct {
+	r *bufio.Reader
+}
+
+// NewKeyReader returns a KeyReader reading from r.
+func NewKeyReader(r io.Reader) *KeyReader {
+	return &KeyReader{r: bufio.NewReader(r)}
+}
+
+// ReadKey returns the next key.
+func (kr *KeyReader) ReadKey() (Key, error) {
+	b, err := kr.r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch b {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, nil
+	case 0x7f, '\b':
+		return Key{Code: KeyBackspace}, nil
+	case 0x03:
+		return Key{Code: KeyInterrupt}, nil
+	case 0x1b:
+		// A terminal sends an escape sequence at once, so a lone ESC byte is the Escape key.
+		if kr.r.Buffered() == 0 {
+			return Key{Code: KeyEscape}, nil
+		}
+		return kr.readEscapeSequence()
+	}
+	if b < 0x20 {
+		return Key{Code: KeyUnknown}, nil
+	}
+
+	if b >= utf8.RuneSelf {
+		if err := kr.r.UnreadByte(); err != nil {
+			return Key{}, err
+		}
+		r, _, err := kr.r.ReadRune()
+		if err != nil {
+			return Key{}, err
+		}
+		return Key{Code: KeyRune, Rune: r}, nil
+	}
+	return Key{Code: KeyRune, Rune: rune(b)}, nil
+}
+
+// readEscapeSequence reads the rest of a CSI ("ESC [") or SS3 ("ESC O") sequence. Unsupported sequences are returned as KeyUnknown.
+func (kr *KeyReader) readEscapeSequence() (Key, error) {
+	introducer, err := kr.r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		return Key{Code: KeyUnknown}, nil
+	}
+
+	// Parameters (digits and semicolons) are followed by the final byte.
+	var params []byte
+	for {
+		b, err := kr.r.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		if b >= '0' && b <= '9' || b == ';' {
+			params = append(params, b)
+			continue
+		}
+
+		switch {
+		case b == 'A':
+			return Key{Code: KeyUp}, nil
+		case b == 'B':
+			return Key{Code: KeyDown}, nil
+		case b == 'C':
+			return Key{Code: KeyRight}, nil
+		case b == 'D':
+			return Key{Code: KeyLeft}, nil
+		case b == '~' && string(params) == "5":
+			return Key{Code: KeyPageUp}, nil
+		case b == '~' && string(params) == "6":
+			return Key{Code: KeyPageDown}, nil
+		}
+		return Key{Code: KeyUnknown}, nil
+	}
+}
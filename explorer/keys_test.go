@@ -0,0 +1,52 @@
+package explorer
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadKey(t *testing.T) {
+	input := "q\x1b[A\x1b[B\x1b[C\x1b[D\x1bOA\x1b[5~\x1b[6~\r\n\x7f\x03é\x1b[1;5C\x01"
+	expected := []Key{
+		{Code: KeyRune, Rune: 'q'},
+		{Code: KeyUp},
+		{Code: KeyDown},
+		{Code: KeyRight},
+		{Code: KeyLeft},
+		{Code: KeyUp},
+		{Code: KeyPageUp},
+		{Code: KeyPageDown},
+		{Code: KeyEnter},
+		{Code: KeyEnter},
+		{Code: KeyBackspace},
+		{Code: KeyInterrupt},
+		{Code: KeyRune, Rune: 'é'},
+		{Code: KeyRight}, // with Ctrl
+		{Code: KeyUnknown},
+	}
+
+	kr := NewKeyReader(strings.NewReader(input))
+	for _, key := range expected {
+		actual, err := kr.ReadKey()
+		assert.NoError(t, err)
+		assert.Equal(t, key, actual)
+	}
+	_, err := kr.ReadKey()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadKeyLoneEscape(t *testing.T) {
+	// The reader gets the escape byte alone, as it happens when the Escape key is pressed.
+	kr := NewKeyReader(io.MultiReader(strings.NewReader("\x1b"), strings.NewReader("x")))
+
+	key, err := kr.ReadKey()
+	assert.NoError(t, err)
+	assert.Equal(t, Key{Code: KeyEscape}, key)
+
+	key, err = kr.ReadKey()
+	assert.NoError(t, err)
+	assert.Equal(t, Key{Code: KeyRune, Rune: 'x'}, key)
+}
@@ -0,0 +1,62 @@
+package explorer
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Terminal is the screen and the keyboard used by Run.
+type Terminal interface {
+	io.Writer
+	// ReadKey waits for the next key.
+	ReadKey() (Key, error)
+	// Size returns the number of columns and rows of the screen.
+	Size() (width, height int, err error)
+}
+
+// Escape sequences controlling the screen.
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l" // switch to the alternate screen and hide the cursor
+	leaveScreen = "\x1b[?25h\x1b[?1049l" // show the cursor and switch back to the main screen
+	home        = "\x1b[H"               // move the cursor to the top left corner
+	clearLine   = "\x1b[K"               // clear the rest of the line
+)
+
+// Run shows the tree on the terminal and handles the keys until the user quits, or the terminal has no more keys (io.EOF).
+func Run(term Terminal, root *printer.Node, expandDepth int) (err error) {
+	if _, err := io.WriteString(term, enterScreen); err != nil {
+		return err
+	}
+	defer func() {
+		if _, leaveErr := io.WriteString(term, leaveScreen); err == nil {
+			err = leaveErr
+		}
+	}()
+
+	e := New(root, expandDepth)
+	for {
+		width, height, err := term.Size()
+		if err != nil {
+			return err
+		}
+		// In raw mode a newline doesn't return the cursor to the first column, and every line is cleared instead of the whole screen to avoid flicker.
+		screen := home + strings.Join(e.View(width, height), clearLine+"\r\n") + clearLine
+		if _, err := io.WriteString(term, screen); err != nil {
+			return err
+		}
+
+		key, err := term.ReadKey()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !e.HandleKey(key, height-1) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,77 @@
+//go:build linux
+
+package explorer
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// TTY is a Linux terminal in raw mode: keys are read as they are pressed, without echo and without line editing.
+type TTY struct {
+	*KeyReader
+	file  *os.File
+	saved syscall.Termios
+}
+
+// OpenTTY opens the controlling terminal of the process (/dev/tty), so that keys are read from it even when stdin is redirected,
+// and switches it to raw mode. Call Close to restore the previous mode.
+func OpenTTY() (*TTY, error) {
+	file, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TTY{KeyReader: NewKeyReader(file), file: file}
+	if err := ioctl(file, syscall.TCGETS, unsafe.Pointer(&t.saved)); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	// The same settings as cfmakeraw(3).
+	raw := t.saved
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(file, syscall.TCSETS, unsafe.Pointer(&raw)); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// Write writes to the terminal.
+func (t *TTY) Write(p []byte) (int, error) {
+	return t.file.Write(p)
+}
+
+// Size returns the size of the terminal.
+func (t *TTY) Size() (width, height int, err error) {
+	var ws struct{ Row, Col, Xpixel, Ypixel uint16 }
+	if err := ioctl(t.file, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// Close restores the mode of the terminal and closes it.
+func (t *TTY) Close() error {
+	err := ioctl(t.file, syscall.TCSETS, unsafe.Pointer(&t.saved))
+	if closeErr := t.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func ioctl(file *os.File, request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), request, uintptr(arg))
+	if errno != 0 {
+		return os.NewSyscallError("ioctl", errno)
+	}
+	return nil
+}
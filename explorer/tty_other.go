@@ -0,0 +1,30 @@
+//go:build !linux
+
+package explorer
+
+import "errors"
+
+// TTY is a terminal in raw mode. It's only supported on Linux.
+type TTY struct {
+	*KeyReader
+}
+
+// OpenTTY returns an error: raw mode is only supported on Linux.
+func OpenTTY() (*TTY, error) {
+	return nil, errors.New("the explorer is only supported on Linux terminals")
+}
+
+// Write is never called, since OpenTTY never succeeds.
+func (t *TTY) Write(p []byte) (int, error) {
+	return 0, errors.ErrUnsupported
+}
+
+// Size is never called, since OpenTTY never succeeds.
+func (t *TTY) Size() (width, height int, err error) {
+	return 0, 0, errors.ErrUnsupported
+}
+
+// Close is never called, since OpenTTY never succeeds.
+func (t *TTY) Close() error {
+	return nil
+}
@@ -0,0 +1,214 @@
+// Package expr parses infix arithmetic expressions into printer.Node trees.
+//
+// The supported syntax, from the lowest to the highest precedence:
+//
+//	a + b, a - b           left-associative
+//	a * b, a / b, a % b    left-associative
+//	-a                     unary minus
+//	a ^ b                  right-associative, binds tighter than unary minus: -a^2 is -(a^2)
+//	f(), f(a), f(a, b)     function calls
+//	(a)                    parentheses
+//
+// Operands are numbers (1, 2.5, .5, 1e-3) and identifiers (x, atan, _tmp1).
+// Binary operators become nodes with two children. Unary minus and single-argument function calls become nodes with a single, left child.
+// A function call without arguments becomes a leaf, e.g. "rand()".
+package expr
+
+import (
+	"fmt"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/syntax"
+)
+
+// SyntaxError describes a problem with the parsed expression and its position.
+type SyntaxError = syntax.Error
+
+// Parse parses the expression and returns its tree.
+// Whitespace, including newlines, is ignored. Errors are of type *SyntaxError.
+func Parse(input string) (*printer.Node, error) {
+	p := &parser{lex: newLexer(input)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	root, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected("an operator")
+	}
+	return root, nil
+}
+
+// MustParse is like Parse, but panics on error. It's meant for expressions known to be valid, e.g. in tests.
+func MustParse(input string) *printer.Node {
+	root, err := Parse(input)
+	if err != nil {
+		panic(err)
+	}
+	return root
+}
+
+// maxCallArgs is the number of arguments that fit under a single node of a binary tree.
+const maxCallArgs = 2
+
+// parser is a recursive descent parser with a single token of lookahead.
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("unexpected %s, expected %s", p.tok.describe(), expected)}
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	if p.tok.kind != tokOperator {
+		return false
+	}
+	for _, op := range ops {
+		if p.tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBinaryLeft parses a left-associative chain of operands separated by the given operators.
+func (p *parser) parseBinaryLeft(operand func() (*printer.Node, error), ops ...string) (*printer.Node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(ops...) {
+		op := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &printer.Node{Value: op, LeftChild: left, RightChild: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (*printer.Node, error) {
+	return p.parseBinaryLeft(p.parseMultiplicative, "+", "-")
+}
+
+func (p *parser) parseMultiplicative() (*printer.Node, error) {
+	return p.parseBinaryLeft(p.parseUnary, "*", "/", "%")
+}
+
+func (p *parser) parseUnary() (*printer.Node, error) {
+	if !p.isOperator("-") {
+		return p.parsePower()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &printer.Node{Value: "-", LeftChild: operand}, nil
+}
+
+func (p *parser) parsePower() (*printer.Node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("^") {
+		return base, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	// The exponent may have its own unary minus (2^-1) and its own exponent (2^3^4 is 2^(3^4)).
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &printer.Node{Value: "^", LeftChild: base, RightChild: exponent}, nil
+}
+
+func (p *parser) parsePrimary() (*printer.Node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		return &printer.Node{Value: tok.text}, p.advance()
+	case tokIdent:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokLParen {
+			return p.parseCall(tok)
+		}
+		return &printer.Node{Value: tok.text}, nil
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.unexpected(`")"`)
+		}
+		return inner, p.advance()
+	}
+	return nil, p.unexpected("a number, an identifier or \"(\"")
+}
+
+// parseCall parses the argument list of a function call. The current token is the opening parenthesis.
+func (p *parser) parseCall(name token) (*printer.Node, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokRParen {
+		return &printer.Node{Value: name.text + "()"}, p.advance()
+	}
+
+	var args []*printer.Node
+	for {
+		if len(args) == maxCallArgs {
+			return nil, &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("function %s has more than %d arguments", name.text, maxCallArgs)}
+		}
+		arg, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if p.tok.kind == tokRParen {
+			break
+		}
+		if p.tok.kind != tokComma {
+			return nil, p.unexpected(`"," or ")"`)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	call := &printer.Node{Value: name.text, LeftChild: args[0]}
+	if len(args) == 2 {
+		call.RightChild = args[1]
+	}
+	return call, p.advance()
+}
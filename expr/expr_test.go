@@ -0,0 +1,97 @@
+package expr
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/ZupkaPomidorowa/print-tree/internal/treetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1", "1"},
+		{"x", "x"},
+		{"2.5", "2.5"},
+		{".5e-3", ".5e-3"},
+		{"1 + 2 * 3", "(+ 1 (* 2 3))"},
+		{"(1 + 2) * 3", "(* (+ 1 2) 3)"},
+		{"1 - 2 - 3", "(- (- 1 2) 3)"},
+		{"8 / 4 / 2", "(/ (/ 8 4) 2)"},
+		{"a % b * c", "(* (% a b) c)"},
+		{"2 ^ 3 ^ 4", "(^ 2 (^ 3 4))"},
+		{"-x", "(- x _)"},
+		{"--x", "(- (- x _) _)"},
+		{"-x ^ 2", "(- (^ x 2) _)"},
+		{"2 ^ -1", "(^ 2 (- 1 _))"},
+		{"a - -b", "(- a (- b _))"},
+		{"atan(x)", "(atan x _)"},
+		{"atan2(y, x + 1)", "(atan2 y (+ x 1))"},
+		{"rand()", "rand()"},
+		{"sin(x)^2 + cos(x)^2", "(+ (^ (sin x _) 2) (^ (cos x _) 2))"},
+		{"((((1))))", "1"},
+		{"1 +\n\t2", "(+ 1 2)"},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, treetest.Shape(actual), tt.input)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", `1:1: unexpected end of input, expected a number, an identifier or "("`},
+		{"1 +", `1:4: unexpected end of input, expected a number, an identifier or "("`},
+		{"1 2", `1:3: unexpected "2", expected an operator`},
+		{"(1 + 2", `1:7: unexpected end of input, expected ")"`},
+		{"1 + 2)", `1:6: unexpected ")", expected an operator`},
+		{"1 + $", `1:5: unexpected character '$'`},
+		{"1 +\n  * 2", `2:3: unexpected "*", expected a number, an identifier or "("`},
+		{"f(1 2)", `1:5: unexpected "2", expected "," or ")"`},
+		{"f(1, 2, 3)", `1:9: function f has more than 2 arguments`},
+		{"1e+", `1:2: missing digits in the exponent`},
+		{"ąę + 1 +\n  ź(", `2:5: unexpected end of input, expected a number, an identifier or "("`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if assert.ErrorAs(t, err, &syntaxErr, tt.input) {
+			assert.Equal(t, tt.expected, err.Error(), tt.input)
+		}
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	assert.Panics(t, func() { MustParse("1 +") })
+}
+
+func TestParsedTreeRendering(t *testing.T) {
+	actual := printer.PrintTree(MustParse("-atan(x) * 2")).String()
+	expected := render.Nlnl(`
+               *
+              / \
+             /   \
+            /     \
+           -       2
+          /
+         /
+        /
+    atan
+   /
+  /
+ /
+x
+`)
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,144 @@
+package expr
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/ZupkaPomidorowa/print-tree/internal/syntax"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOperator // + - * / % ^
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of input"
+	case tokNumber:
+		return "number"
+	case tokIdent:
+		return "identifier"
+	case tokOperator:
+		return "operator"
+	case tokLParen:
+		return `"("`
+	case tokRParen:
+		return `")"`
+	case tokComma:
+		return `","`
+	}
+	return "unknown token"
+}
+
+// Position is a location in the parsed input. Both the line and the column start at 1, the column counts runes, not bytes.
+type Position = syntax.Position
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  Position
+}
+
+func (t token) describe() string {
+	if t.kind == tokEOF {
+		return t.kind.String()
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lexer splits the input into tokens, keeping track of the line and the column of every token.
+type lexer struct {
+	syntax.Scanner
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{Scanner: syntax.NewScanner(input)}
+}
+
+func (l *lexer) acceptDigits() {
+	for !l.AtEnd() && isDigit(l.PeekRune()) {
+		l.NextRune()
+	}
+}
+
+// next returns the next token, or a *SyntaxError if the input contains a character that doesn't start any token.
+func (l *lexer) next() (token, error) {
+	for !l.AtEnd() && unicode.IsSpace(l.PeekRune()) {
+		l.NextRune()
+	}
+
+	start := l.Offset
+	pos := l.Pos
+	if l.AtEnd() {
+		return token{kind: tokEOF, pos: pos}, nil
+	}
+
+	var kind tokenKind
+	r := l.NextRune()
+	switch {
+	case isDigit(r) || (r == '.' && isDigit(l.PeekRune())):
+		kind = tokNumber
+		l.acceptDigits()
+		if r != '.' && l.PeekRune() == '.' {
+			l.NextRune()
+			l.acceptDigits()
+		}
+		if p := l.PeekRune(); p == 'e' || p == 'E' {
+			if err := l.acceptExponent(); err != nil {
+				return token{}, err
+			}
+		}
+	case isIdentStart(r):
+		kind = tokIdent
+		for !l.AtEnd() && isIdentPart(l.PeekRune()) {
+			l.NextRune()
+		}
+	case r == '+' || r == '-' || r == '*' || r == '/' || r == '%' || r == '^':
+		kind = tokOperator
+	case r == '(':
+		kind = tokLParen
+	case r == ')':
+		kind = tokRParen
+	case r == ',':
+		kind = tokComma
+	default:
+		return token{}, &SyntaxError{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+	}
+
+	return token{kind: kind, text: l.Input[start:l.Offset], pos: pos}, nil
+}
+
+// acceptExponent consumes the "e", optional sign and digits of a number's exponent.
+func (l *lexer) acceptExponent() error {
+	pos := l.Pos
+	l.NextRune()
+	if p := l.PeekRune(); p == '+' || p == '-' {
+		l.NextRune()
+	}
+	if !isDigit(l.PeekRune()) {
+		return &SyntaxError{Pos: pos, Msg: "missing digits in the exponent"}
+	}
+	l.acceptDigits()
+	return nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
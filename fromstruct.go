@@ -0,0 +1,180 @@
+package printer
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FromStruct builds a tree out of arbitrary pointer-linked structs, using struct tags to find the label and the children of every node:
+//
+//	type Expr struct {
+//		Op    string `tree:"label"`
+//		Left  *Expr  `tree:"left"`
+//		Right *Expr  `tree:"right"`
+//	}
+//
+//	type Dir struct {
+//		Name    string `tree:"label"`
+//		Entries []*Dir `tree:"children"`
+//	}
+//
+// The "label" field is printed with fmt.Sprint(). Without such a field, a node must implement fmt.Stringer
+// (which doesn't work for the nodes kept in unexported fields, because their methods can't be called through reflection).
+// The "left" and "right" fields hold pointers to the children (nil for a missing child).
+// The "children" field is a slice of any length and is folded with Group(). It can't be combined with "left" and "right".
+// The argument is either a pointer to a struct or a struct. An error is returned for nil pointers and for cycles.
+func FromStruct(v any) (*Node, error) {
+	b := &structBuilder{types: map[reflect.Type]*structFields{}, path: map[structAddr]bool{}}
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return nil, fmt.Errorf("nil value")
+	}
+	return b.build(value)
+}
+
+// structFields holds the indices of the tagged fields of a struct type, -1 for a missing field.
+type structFields struct {
+	label, left, right, children int
+}
+
+// structAddr identifies a struct in memory. The address alone isn't enough, because a struct and its first field share it.
+type structAddr struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+type structBuilder struct {
+	types map[reflect.Type]*structFields
+	// path holds the structs between the root and the current node, to detect cycles.
+	path map[structAddr]bool
+}
+
+func (b *structBuilder) build(value reflect.Value) (*Node, error) {
+	for value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	node := &Node{}
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, fmt.Errorf("nil %s", value.Type())
+		}
+		key := structAddr{value.Type(), value.Pointer()}
+		if b.path[key] {
+			return nil, fmt.Errorf("cycle: %s at %#x refers back to itself", key.typ, key.addr)
+		}
+		b.path[key] = true
+		defer delete(b.path, key)
+
+		if s, ok := stringer(value); ok {
+			node.Value = s
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct or a pointer to a struct, found %s", value.Type())
+	}
+
+	fields, err := b.fields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case fields.label >= 0:
+		node.Value = fmt.Sprint(value.Field(fields.label))
+	case node.Value == "":
+		s, ok := stringer(value)
+		if !ok {
+			return nil, fmt.Errorf("%s has no field tagged `tree:\"label\"` and doesn't implement fmt.Stringer", value.Type())
+		}
+		node.Value = s
+	}
+	if node.Value == "" {
+		return nil, fmt.Errorf("empty label of %s", value.Type())
+	}
+
+	if fields.children >= 0 {
+		list := value.Field(fields.children)
+		var children []*Node
+		for i := 0; i < list.Len(); i++ {
+			child, err := b.child(list.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		return Group(node.Value, children...), nil
+	}
+
+	if fields.left >= 0 {
+		if node.LeftChild, err = b.child(value.Field(fields.left)); err != nil {
+			return nil, err
+		}
+	}
+	if fields.right >= 0 {
+		if node.RightChild, err = b.child(value.Field(fields.right)); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// child builds the node of a child field, or returns nil for a nil child.
+func (b *structBuilder) child(value reflect.Value) (*Node, error) {
+	if (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) && value.IsNil() {
+		return nil, nil
+	}
+	return b.build(value)
+}
+
+// stringer returns the result of the String() method, if the value implements fmt.Stringer and is accessible.
+func stringer(value reflect.Value) (string, bool) {
+	if !value.CanInterface() {
+		return "", false
+	}
+	s, ok := value.Interface().(fmt.Stringer)
+	if !ok {
+		return "", false
+	}
+	return s.String(), true
+}
+
+// fields returns the tagged fields of the struct type.
+func (b *structBuilder) fields(t reflect.Type) (*structFields, error) {
+	if f, ok := b.types[t]; ok {
+		return f, nil
+	}
+
+	f := &structFields{label: -1, left: -1, right: -1, children: -1}
+	for i := 0; i < t.NumField(); i++ {
+		var index *int
+		switch tag := t.Field(i).Tag.Get("tree"); tag {
+		case "":
+			continue
+		case "label":
+			index = &f.label
+		case "left":
+			index = &f.left
+		case "right":
+			index = &f.right
+		case "children":
+			index = &f.children
+			if kind := t.Field(i).Type.Kind(); kind != reflect.Slice && kind != reflect.Array {
+				return nil, fmt.Errorf("%s.%s: the children field must be a slice or an array, found %s", t, t.Field(i).Name, kind)
+			}
+		default:
+			return nil, fmt.Errorf("%s.%s: unknown tag `tree:%q`", t, t.Field(i).Name, tag)
+		}
+		if *index >= 0 {
+			return nil, fmt.Errorf("%s.%s: more than one field tagged `tree:%q`", t, t.Field(i).Name, t.Field(i).Tag.Get("tree"))
+		}
+		*index = i
+	}
+
+	if f.children >= 0 && (f.left >= 0 || f.right >= 0) {
+		return nil, fmt.Errorf("%s: the children field can't be combined with the left and right fields", t)
+	}
+	b.types[t] = f
+	return f, nil
+}
@@ -0,0 +1,143 @@
+package printer_test
+
+import (
+	"strconv"
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/treetest"
+	"github.com/stretchr/testify/assert"
+)
+
+type exprNode struct {
+	Op    string    `tree:"label"`
+	Left  *exprNode `tree:"left"`
+	Right *exprNode `tree:"right"`
+}
+
+type dirNode struct {
+	Name    string     `tree:"label"`
+	Size    int        // not a part of the tree
+	Entries []*dirNode `tree:"children"`
+}
+
+// numNode has no label field, its label comes from the String() method.
+type numNode struct {
+	n     int
+	Left  *numNode `tree:"left"`
+	Right any      `tree:"right"`
+}
+
+func (n *numNode) String() string {
+	return "#" + strconv.Itoa(n.n)
+}
+
+func TestFromStruct(t *testing.T) {
+	expr := &exprNode{
+		Op:    "+",
+		Left:  &exprNode{Op: "1"},
+		Right: &exprNode{Op: "-", Left: &exprNode{Op: "x"}},
+	}
+	actual, err := printer.FromStruct(expr)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(+ 1 (- x _))", treetest.Shape(actual))
+	}
+
+	actual, err = printer.FromStruct(*expr)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(+ 1 (- x _))", treetest.Shape(actual))
+	}
+
+	dir := &dirNode{Name: "/", Entries: []*dirNode{{Name: "bin"}, {Name: "etc", Entries: []*dirNode{{Name: "hosts"}}}, nil, {Name: "usr"}}}
+	actual, err = printer.FromStruct(dir)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(/ (... bin (etc hosts _)) usr)", treetest.Shape(actual))
+	}
+
+	shared := &numNode{n: 3}
+	num := &numNode{n: 1, Left: shared, Right: &numNode{n: 2, Right: shared}}
+	actual, err = printer.FromStruct(num)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(#1 #3 (#2 _ #3))", treetest.Shape(actual))
+	}
+
+	actual, err = printer.FromStruct(&privateNode{label: "a", left: &privateNode{label: "b"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(a b _)", treetest.Shape(actual))
+	}
+
+	outer := &outerNode{Inner: privateNode{label: "inner"}, Name: "outer"}
+	outer.Left = &outer.Inner
+	actual, err = printer.FromStruct(outer)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(outer inner _)", treetest.Shape(actual))
+	}
+}
+
+// outerNode points to its own first field, which has the same address as the node but isn't the same struct.
+type outerNode struct {
+	Inner privateNode
+	Name  string       `tree:"label"`
+	Left  *privateNode `tree:"left"`
+}
+
+type unlabelled struct {
+	Left *unlabelled `tree:"left"`
+}
+
+// privateNode keeps the children in unexported fields.
+type privateNode struct {
+	label string       `tree:"label"`
+	left  *privateNode `tree:"left"`
+}
+
+type badTag struct {
+	Name string `tree:"name"`
+}
+
+type twoLabels struct {
+	A string `tree:"label"`
+	B string `tree:"label"`
+}
+
+type mixedChildren struct {
+	Name     string           `tree:"label"`
+	Left     *mixedChildren   `tree:"left"`
+	Children []*mixedChildren `tree:"children"`
+}
+
+type notSliceChildren struct {
+	Name     string `tree:"label"`
+	Children int    `tree:"children"`
+}
+
+func TestFromStructErrors(t *testing.T) {
+	cycle := &exprNode{Op: "a", Left: &exprNode{Op: "b"}}
+	cycle.Left.Right = cycle
+
+	var nilExpr *exprNode
+
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{nil, "nil value"},
+		{nilExpr, "nil *printer_test.exprNode"},
+		{42, "expected a struct or a pointer to a struct, found int"},
+		{&exprNode{}, "empty label of printer_test.exprNode"},
+		{&unlabelled{}, "printer_test.unlabelled has no field tagged `tree:\"label\"` and doesn't implement fmt.Stringer"},
+		{&badTag{}, "printer_test.badTag.Name: unknown tag `tree:\"name\"`"},
+		{&twoLabels{}, "printer_test.twoLabels.B: more than one field tagged `tree:\"label\"`"},
+		{&mixedChildren{}, "printer_test.mixedChildren: the children field can't be combined with the left and right fields"},
+		{&notSliceChildren{}, "printer_test.notSliceChildren.Children: the children field must be a slice or an array, found int"},
+		{&numNode{n: 1, Right: 5}, "expected a struct or a pointer to a struct, found int"},
+	}
+
+	for _, tt := range tests {
+		_, err := printer.FromStruct(tt.value)
+		assert.EqualError(t, err, tt.expected)
+	}
+
+	_, err := printer.FromStruct(cycle)
+	assert.ErrorContains(t, err, "cycle: *printer_test.exprNode at 0x")
+}
@@ -0,0 +1,171 @@
+// Package goast converts Go syntax trees (go/ast) into printer.Node trees.
+//
+// Operators, identifiers and literals are shown as they appear in the source: a BinaryExpr is labelled with its operator
+// and has the operands as its children, a UnaryExpr has a single child, a CallExpr has the called function on the left
+// and the arguments on the right. Other nodes are labelled with a short name of their type (e.g. "if", "return", "ExprStmt").
+// Nodes with more than two children are folded with printer.Group().
+package goast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// FromNode converts the given Go syntax tree into a printer tree. Returns nil for a nil node.
+func FromNode(n ast.Node) *printer.Node {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return nil
+	}
+
+	switch n := n.(type) {
+	case *ast.Ident:
+		return &printer.Node{Value: n.Name}
+	case *ast.BasicLit:
+		if strings.Contains(n.Value, "\n") {
+			// A multi-line raw string would break the rows of the rendering.
+			return &printer.Node{Value: strconv.Quote(n.Value)}
+		}
+		return &printer.Node{Value: n.Value}
+	case *ast.BinaryExpr:
+		return printer.Group(n.Op.String(), FromNode(n.X), FromNode(n.Y))
+	case *ast.UnaryExpr:
+		return printer.Group(n.Op.String(), FromNode(n.X))
+	case *ast.StarExpr:
+		return printer.Group("*", FromNode(n.X))
+	case *ast.CallExpr:
+		return printer.Group("call", FromNode(n.Fun), list("args", n.Args))
+	case *ast.AssignStmt:
+		return printer.Group(n.Tok.String(), list("lhs", n.Lhs), list("rhs", n.Rhs))
+	case *ast.IncDecStmt:
+		return printer.Group(n.Tok.String(), FromNode(n.X))
+	case *ast.BranchStmt:
+		return printer.Group(n.Tok.String(), FromNode(n.Label))
+	case *ast.FuncDecl:
+		return printer.Group("func", directChildren(n)...)
+	}
+
+	return printer.Group(label(n), directChildren(n)...)
+}
+
+// labels holds the labels of node types that read better as keywords than as type names.
+var labels = map[string]string{
+	"BlockStmt":    "{}",
+	"CompositeLit": "{}lit",
+	"FuncLit":      "funclit",
+	"IfStmt":       "if",
+	"ForStmt":      "for",
+	"RangeStmt":    "range",
+	"ReturnStmt":   "return",
+	"SwitchStmt":   "switch",
+	"CaseClause":   "case",
+	"DeferStmt":    "defer",
+	"GoStmt":       "go",
+	"ParenExpr":    "()",
+	"SelectorExpr": ".",
+	"IndexExpr":    "[]",
+	"SliceExpr":    "[:]",
+	"KeyValueExpr": ":",
+}
+
+// label returns the label of a node that has no dedicated conversion: either a keyword from labels or the name of its type.
+func label(n ast.Node) string {
+	name := reflect.TypeOf(n).Elem().Name()
+	if l, ok := labels[name]; ok {
+		return l
+	}
+	return name
+}
+
+// directChildren returns the converted children of the node, in the source order.
+func directChildren(n ast.Node) []*printer.Node {
+	var children []*printer.Node
+	ast.Inspect(n, func(child ast.Node) bool {
+		if child == n {
+			return true
+		}
+		if child != nil {
+			children = append(children, FromNode(child))
+		}
+		return false
+	})
+	return children
+}
+
+// list converts a list of nodes that is a single child of its parent: an empty list is skipped, a single node is attached directly
+// and more nodes are grouped under a node with the given label.
+func list(label string, nodes []ast.Expr) *printer.Node {
+	switch len(nodes) {
+	case 0:
+		return nil
+	case 1:
+		return FromNode(nodes[0])
+	}
+	var children []*printer.Node
+	for _, n := range nodes {
+		children = append(children, FromNode(n))
+	}
+	return printer.Group(label, children...)
+}
+
+// ParseExpr parses a Go expression and converts it into a printer tree.
+func ParseExpr(src string) (*printer.Node, error) {
+	expr, err := parser.ParseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return FromNode(expr), nil
+}
+
+// ParseFunc parses a Go source file and converts the declaration of the given function into a printer tree.
+// Methods are selected with the receiver type name: "Type.Method".
+// The src argument is passed to parser.ParseFile(): if it's nil, the file is read from the filename.
+func ParseFunc(filename string, src any, funcName string) (*printer.Node, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	recvName, name, isMethod := strings.Cut(funcName, ".")
+	if !isMethod {
+		name, recvName = recvName, ""
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != name || receiverName(fn) != recvName {
+			continue
+		}
+		return FromNode(fn), nil
+	}
+	return nil, fmt.Errorf("%s: function %s not found", filename, funcName)
+}
+
+// receiverName returns the name of the receiver's type of a method (without the pointer and type parameters), or an empty string for a function.
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
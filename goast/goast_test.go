@@ -0,0 +1,96 @@
+package goast
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/ZupkaPomidorowa/print-tree/internal/treetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a", "a"},
+		{"a*(b+c)", "(* a (() (+ b c) _))"},
+		{"-x", "(- x _)"},
+		{"*p", "(* p _)"},
+		{"f()", "(call f _)"},
+		{"f(x)", "(call f x)"},
+		{"atan2(y, x)", "(call atan2 (args y x))"},
+		{"fmt.Println(1, 2, 3)", "(call (. fmt Println) (args (... 1 2) 3))"},
+		{`s[i] + "x"`, `(+ ([] s i) "x")`},
+		{"`a\nb`", "\"`a\\nb`\""},
+	}
+
+	for _, tt := range tests {
+		actual, err := ParseExpr(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, treetest.Shape(actual), tt.input)
+		}
+	}
+}
+
+func TestParseExprError(t *testing.T) {
+	_, err := ParseExpr("a +")
+	assert.EqualError(t, err, "1:4: expected operand, found 'EOF'")
+}
+
+const source = `package sample
+
+type T struct{}
+
+func (t *T) Inc(x int) int {
+	x++
+	return x
+}
+
+func Inc(a, b int) int {
+	a, b = b, a
+	return a
+}
+`
+
+func TestParseFunc(t *testing.T) {
+	tests := []struct {
+		funcName string
+		expected string
+	}{
+		{"T.Inc", "(func (... (FieldList (Field t (* T _)) _) Inc) (... (FuncType (FieldList (Field x int) _) (FieldList (Field int _) _)) ({} (++ x _) (return x _))))"},
+		{"Inc", "(func (... Inc (FuncType (FieldList (Field (... a b) int) _) (FieldList (Field int _) _))) ({} (= (lhs a b) (rhs b a)) (return a _)))"},
+	}
+
+	for _, tt := range tests {
+		actual, err := ParseFunc("sample.go", source, tt.funcName)
+		if assert.NoError(t, err, tt.funcName) {
+			assert.Equal(t, tt.expected, treetest.Shape(actual), tt.funcName)
+		}
+	}
+
+	_, err := ParseFunc("sample.go", source, "Dec")
+	assert.EqualError(t, err, "sample.go: function Dec not found")
+
+	_, err = ParseFunc("sample.go", "package", "Inc")
+	assert.Error(t, err)
+}
+
+func TestParseExprRendering(t *testing.T) {
+	root, err := ParseExpr("x.y != nil")
+	assert.NoError(t, err)
+
+	expected := render.Nlnl(`
+        !=
+       /  \
+      /    \
+     /      \
+    .        nil
+   / \
+  /   \
+ /     \
+x       y
+`)
+	assert.Equal(t, expected, printer.PrintTree(root).String())
+}
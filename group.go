@@ -0,0 +1,41 @@
+package printer
+
+// GroupValue is the value of the intermediate nodes created by Group().
+const GroupValue = "..."
+
+// Group builds a node with an arbitrary number of children, e.g. for trees that are not binary by nature.
+// Nil children are skipped. Up to two children are attached directly: a single child becomes the left child.
+// More children are split in two halves, and every half with more than one child is attached through an intermediate node with the GroupValue value.
+// This keeps the children in their original order (from left to right) and the resulting tree balanced.
+func Group(value string, children ...*Node) *Node {
+	var present []*Node
+	for _, child := range children {
+		if child != nil {
+			present = append(present, child)
+		}
+	}
+	return group(value, present)
+}
+
+func group(value string, children []*Node) *Node {
+	node := &Node{Value: value}
+	switch len(children) {
+	case 0:
+	case 1:
+		node.LeftChild = children[0]
+	case 2:
+		node.LeftChild, node.RightChild = children[0], children[1]
+	default:
+		half := (len(children) + 1) / 2
+		node.LeftChild = groupHalf(children[:half])
+		node.RightChild = groupHalf(children[half:])
+	}
+	return node
+}
+
+func groupHalf(children []*Node) *Node {
+	if len(children) == 1 {
+		return children[0]
+	}
+	return group(GroupValue, children)
+}
@@ -0,0 +1,53 @@
+package printer_test
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/ZupkaPomidorowa/print-tree/internal/treetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func leaves(values ...string) []*printer.Node {
+	var result []*printer.Node
+	for _, v := range values {
+		result = append(result, &printer.Node{Value: v})
+	}
+	return result
+}
+
+func TestGroup(t *testing.T) {
+	tests := []struct {
+		children []*printer.Node
+		expected string
+	}{
+		{nil, "g"},
+		{leaves("a"), "(g a _)"},
+		{leaves("a", "b"), "(g a b)"},
+		{leaves("a", "b", "c"), "(g (... a b) c)"},
+		{leaves("a", "b", "c", "d"), "(g (... a b) (... c d))"},
+		{leaves("a", "b", "c", "d", "e"), "(g (... (... a b) c) (... d e))"},
+		{[]*printer.Node{nil, {Value: "a"}, nil, {Value: "b"}}, "(g a b)"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, treetest.Shape(printer.Group("g", tt.children...)))
+	}
+}
+
+func TestGroupRendering(t *testing.T) {
+	actual := printer.PrintTree(printer.Group("dir", leaves("a", "b", "c")...))
+	expected := render.Nlnl(`
+          dir
+         /   \
+        /     \
+       /       \
+    ...         c
+   /   \
+  /     \
+ /       \
+a         b
+`)
+	assert.Equal(t, expected, actual.String())
+}
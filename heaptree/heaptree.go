@@ -0,0 +1,157 @@
+// Package heaptree shows slices used as binary heaps (e.g. with container/heap) as trees.
+//
+// The item at the index i has its children at the indices 2*i+1 and 2*i+2, so the slice is drawn as a complete binary tree:
+// all the levels are full, except for the last one, which is filled from the left.
+// Draw can also write the index of every item in a line under its value.
+package heaptree
+
+import (
+	"strconv"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// ViolationMark is appended to the values of both items of every parent and child pair that violates the heap property,
+// i.e. where the child should be above its parent.
+const ViolationMark = "!"
+
+// Options controls the values of the nodes created by Tree().
+type Options struct {
+	// ShowIndices shows the index of every item. Draw writes it under the value, e.g. "[3]".
+	// A node has a single line value, so Tree appends it to the value instead, e.g. "42[3]".
+	ShowIndices bool
+	// Less is the ordering of the heap: the item at the index i should be above the item at the index j if Less(i, j).
+	// It has the same signature as the Less method of heap.Interface (and sort.Interface), so that method can be used directly.
+	// If it's set, the parents and the children that violate the heap property are marked with the ViolationMark.
+	Less func(i, j int) bool
+}
+
+// Tree returns the tree of the heap stored in the items. The format function returns the value of a single item.
+// Returns nil for an empty slice.
+func Tree[T any](items []T, format func(T) string, opts Options) *printer.Node {
+	violations := map[int]bool{}
+	if opts.Less != nil {
+		for _, i := range Violations(len(items), opts.Less) {
+			violations[i] = true
+			violations[(i-1)/2] = true
+		}
+	}
+
+	nodes := make([]*printer.Node, len(items))
+	for i, item := range items {
+		value := format(item)
+		if value == "" {
+			value = `""`
+		}
+		if opts.ShowIndices {
+			value += "[" + strconv.Itoa(i) + "]"
+		}
+		if violations[i] {
+			value += ViolationMark
+		}
+		nodes[i] = &printer.Node{Value: value}
+	}
+
+	for i, node := range nodes {
+		if left := 2*i + 1; left < len(nodes) {
+			node.LeftChild = nodes[left]
+		}
+		if right := 2*i + 2; right < len(nodes) {
+			node.RightChild = nodes[right]
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// Draw returns the lines of the drawing of the heap, like printer.Draw() of the Tree, but with ShowIndices the index of every item
+// is written in the line under its value (Fig. 1). The index lines are added after the lines of values, so the connectors
+// start one line lower, and the tree is drawn wider where the indices are wider than the values. Returns nil for an empty slice.
+func Draw[T any](items []T, format func(T) string, opts Options) []string {
+	showIndices := opts.ShowIndices
+	opts.ShowIndices = false
+	root := Tree(items, format, opts)
+	if root == nil {
+		return nil
+	}
+
+	var indices map[int]string
+	if showIndices {
+		indices = indexLines(root)
+	}
+	var lines []string
+	for i, line := range printer.Draw(root, printer.DrawOptions{}) {
+		lines = append(lines, strings.TrimRight(line.Text, " "))
+		if index, ok := indices[i]; ok {
+			lines = append(lines, index)
+		}
+	}
+	return lines
+}
+
+// indexLines returns the lines of indices of the drawing of the heap tree, by the rows of the values they go under.
+// The values are padded to the width of their indices first, so that the tree is drawn with room for the indices,
+// and every index can be centered under its value.
+func indexLines(root *printer.Node) map[int]string {
+	// The nodes are numbered in level order, which is the order of the items.
+	labels := map[*printer.Node]string{}
+	for queue := []*printer.Node{root}; len(queue) > 0; queue = queue[1:] {
+		n := queue[0]
+		labels[n] = "[" + strconv.Itoa(len(labels)) + "]"
+		n.Value = center(n.Value, len(labels[n]))
+		for _, child := range []*printer.Node{n.LeftChild, n.RightChild} {
+			if child != nil {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	lines := map[int]string{}
+	for _, p := range printer.Place(root, printer.DrawOptions{}) {
+		line := lines[p.Row]
+		lines[p.Row] = line + strings.Repeat(" ", p.Column-len(line)) + strings.TrimRight(center(labels[p.Node], len(p.Node.Value)), " ")
+	}
+	return lines
+}
+
+// center pads the text with spaces on both sides to the width, with the extra space on the right.
+func center(text string, width int) string {
+	pad := max(width-len(text), 0)
+	return strings.Repeat(" ", pad/2) + text + strings.Repeat(" ", pad-pad/2)
+}
+
+// Violations returns the indices of the items that should be above their parents according to less, in the increasing order.
+// The heap property holds if the result is empty.
+func Violations(n int, less func(i, j int) bool) []int {
+	var result []int
+	for i := 1; i < n; i++ {
+		if less(i, (i-1)/2) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+/*
+
+------------------------------------------------------------
+Fig. 1 - A heap drawn with indices and violations
+
+             1
+            [0]
+           /   \
+          /     \
+         /       \
+      3!           2
+      [1]         [2]
+     /
+    /
+   /
+0!
+[3]
+
+*/
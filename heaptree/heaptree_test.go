@@ -0,0 +1,116 @@
+package heaptree
+
+import (
+	"container/heap"
+	"strconv"
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+// intHeap is the min-heap from the container/heap example.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		items    intHeap
+		opts     func(h intHeap) Options
+		expected string
+	}{
+		{intHeap{1}, func(intHeap) Options { return Options{} }, "1"},
+		{intHeap{1, 2, 3, 4, 5, 6}, func(intHeap) Options { return Options{} }, "(1 (2 4 5) (3 6))"},
+		{intHeap{1, 2, 3, 4}, func(intHeap) Options { return Options{ShowIndices: true} }, "(1[0] (2[1] 4[3]) 3[2])"},
+		{intHeap{5, 2, 7, 1}, func(h intHeap) Options { return Options{Less: h.Less} }, "(5! (2! 1!) 7)"},
+		{intHeap{5, 2, 7, 1}, func(h intHeap) Options { return Options{Less: h.Less, ShowIndices: true} }, "(5[0]! (2[1]! 1[3]!) 7[2])"},
+	}
+
+	for _, tt := range tests {
+		actual := Tree(tt.items, strconv.Itoa, tt.opts(tt.items))
+		assert.Equal(t, tt.expected, sexpr.Format(actual))
+	}
+
+	assert.Nil(t, Tree(intHeap{}, strconv.Itoa, Options{}))
+	assert.Equal(t, `""`, Tree([]string{""}, func(s string) string { return s }, Options{}).Value)
+}
+
+func TestViolations(t *testing.T) {
+	h := &intHeap{5, 2, 8}
+	heap.Init(h)
+	heap.Push(h, 3)
+	heap.Push(h, 1)
+	assert.Empty(t, Violations(h.Len(), h.Less))
+
+	(*h)[0] = 9
+	assert.Equal(t, []int{1, 2}, Violations(h.Len(), h.Less))
+}
+
+func TestTreeRendering(t *testing.T) {
+	h := intHeap{1, 3, 2, 0}
+	actual := printer.PrintTree(Tree(h, strconv.Itoa, Options{Less: h.Less}))
+
+	expected := render.Nlnl(`
+          1
+         / \
+        /   \
+       /     \
+     3!       2
+    /
+   /
+  /
+0!
+`)
+	assert.Equal(t, expected, actual.String())
+}
+
+func TestDraw(t *testing.T) {
+	h := intHeap{1, 3, 2, 0}
+	expected := []string{
+		"             1",
+		"            [0]",
+		"           /   \\",
+		"          /     \\",
+		"         /       \\",
+		"      3!           2",
+		"      [1]         [2]",
+		"     /",
+		"    /",
+		"   /",
+		"0!",
+		"[3]",
+	}
+	assert.Equal(t, expected, Draw(h, strconv.Itoa, Options{ShowIndices: true, Less: h.Less}))
+
+	// Without indices, the lines are the lines of the drawing of the tree.
+	root := Tree(h, strconv.Itoa, Options{})
+	var lines []string
+	for _, line := range printer.Draw(root, printer.DrawOptions{}) {
+		lines = append(lines, line.Text)
+	}
+	assert.Equal(t, lines, Draw(h, strconv.Itoa, Options{}))
+	assert.Nil(t, Draw(intHeap{}, strconv.Itoa, Options{ShowIndices: true}))
+
+	// The tree is drawn wider for indices wider than their values, and every index stays centered under its value.
+	wide := Draw(make([]int, 12), strconv.Itoa, Options{ShowIndices: true})
+	assert.Equal(t, " 0           0     0           0       0", wide[len(wide)-2])
+	assert.Equal(t, "[7]         [8]   [9]         [10]    [11]", wide[len(wide)-1])
+	for column, r := range wide[len(wide)-2] {
+		if r == '0' {
+			assert.Contains(t, "0123456789", string(wide[len(wide)-1][column]), column)
+		}
+	}
+}
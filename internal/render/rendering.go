@@ -119,6 +119,21 @@ func (pr *Rendering) NormalizeOffsetsRev() *Rendering {
 	return pr
 }
 
+// TrimLeft moves the left edge of the rendering to the leftmost row, so that String() doesn't print empty columns on the left.
+// Renderings never have empty columns on the left, unless one of the joined renderings was empty (see JoinRenderings()).
+func (pr *Rendering) TrimLeft() *Rendering {
+	if len(pr.Rows) == 0 {
+		return pr
+	}
+	pr.minIndex = pr.Rows[0].offset
+	for _, row := range pr.Rows[1:] {
+		if row.offset < pr.minIndex {
+			pr.minIndex = row.offset
+		}
+	}
+	return pr
+}
+
 // Reverse reverses the vertical order of the rows in the rendering.
 func (pr *Rendering) Reverse() {
 
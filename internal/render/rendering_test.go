@@ -358,3 +358,22 @@ func TestDoubleNormalize(t *testing.T) {
 	assert.Equal(t, "", pr.GetRow(2).Prefix())
 	assert.Equal(t, "foo", pr.GetRow(2).Suffix())
 }
+
+func TestTrimLeft(t *testing.T) {
+	pr := NewPartialRendering("foo")
+	pr.ShiftTopBy(3)
+	pr.AddOnTop("bar").ShiftTopBy(2)
+
+	assert.Equal(t, Nlnl(`
+  bar
+   foo
+`), pr.String())
+
+	pr.TrimLeft()
+	assert.Equal(t, Nlnl(`
+bar
+ foo
+`), pr.String())
+
+	assert.Equal(t, 0, NewEmptyRendering().TrimLeft().Width())
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"io"
+	"strings"
+)
+
+// WriteOptions controls how a Rendering is written out by WriteWithOptions().
+// The zero value produces exactly the same text as the String() method.
+type WriteOptions struct {
+	// LineEnding is appended to every row. Empty means "\n".
+	LineEnding string
+	// TrimTrailingSpace removes spaces (and tabs) at the end of every row.
+	// Rows produced by the printer never end with a space, but node values might.
+	TrimTrailingSpace bool
+	// PadRight fills every row with spaces up to the width of the widest row, so the output is a rectangle.
+	// It is ignored when TrimTrailingSpace is set.
+	PadRight bool
+}
+
+// Width returns the number of columns occupied by the widest row.
+func (pr *Rendering) Width() int {
+	width := 0
+	for _, row := range pr.Rows {
+		if w := -pr.minIndex + row.offset + len(row.val); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// Height returns the number of rows.
+func (pr *Rendering) Height() int {
+	return len(pr.Rows)
+}
+
+// Line returns the n-th row from the top, indented like in String(), but without the line ending.
+// It allows to go through the rows without building the whole picture.
+func (pr *Rendering) Line(n int) string {
+	row := pr.GetRow(n)
+	return Spaces(-pr.minIndex+row.offset) + row.val
+}
+
+// WriteTo writes the rendering to w row by row, without building the whole picture in memory first.
+// It implements io.WriterTo and produces the same text as String().
+func (pr *Rendering) WriteTo(w io.Writer) (int64, error) {
+	return pr.WriteWithOptions(w, WriteOptions{})
+}
+
+// WriteWithOptions writes the rendering to w row by row, starting from the top row.
+// Every row results in a single Write call, so the memory used is proportional to the widest row, not to the whole picture.
+// The first write error stops the output and is returned together with the number of bytes written so far.
+func (pr *Rendering) WriteWithOptions(w io.Writer, opts WriteOptions) (int64, error) {
+	lineEnding := opts.LineEnding
+	if lineEnding == "" {
+		lineEnding = "\n"
+	}
+
+	width := 0
+	if opts.PadRight && !opts.TrimTrailingSpace {
+		width = pr.Width()
+	}
+
+	var total int64
+	var line []byte
+	for i := len(pr.Rows) - 1; i >= 0; i-- {
+		row := pr.Rows[i]
+		val := row.val
+		if opts.TrimTrailingSpace {
+			val = strings.TrimRight(val, " \t")
+		}
+
+		line = line[:0]
+		if val != "" || !opts.TrimTrailingSpace {
+			line = appendSpaces(line, -pr.minIndex+row.offset)
+			line = append(line, val...)
+		}
+		line = appendSpaces(line, width-len(line))
+		line = append(line, lineEnding...)
+
+		n, err := w.Write(line)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func appendSpaces(b []byte, count int) []byte {
+	for ; count > 0; count-- {
+		b = append(b, ' ')
+	}
+	return b
+}
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildWriteRendering() *Rendering {
+	r := NewPartialRendering("foo ")
+	r.AddOnTop("bar").ShiftTopBy(-2)
+	r.AddOnTop("bazbaz").ShiftTopBy(1)
+	return r
+}
+
+func TestWriteToMatchesString(t *testing.T) {
+	r := buildWriteRendering()
+
+	var buf bytes.Buffer
+	n, err := r.WriteTo(&buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, r.String(), buf.String())
+	assert.Equal(t, int64(buf.Len()), n)
+}
+
+func TestWriteWithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     WriteOptions
+		expected string
+	}{
+		{"default", WriteOptions{}, "   bazbaz\nbar\n  foo \n"},
+		{"crlf", WriteOptions{LineEnding: "\r\n"}, "   bazbaz\r\nbar\r\n  foo \r\n"},
+		{"trim", WriteOptions{TrimTrailingSpace: true}, "   bazbaz\nbar\n  foo\n"},
+		{"pad", WriteOptions{PadRight: true}, "   bazbaz\nbar      \n  foo    \n"},
+		{"trim wins over pad", WriteOptions{TrimTrailingSpace: true, PadRight: true}, "   bazbaz\nbar\n  foo\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		_, err := buildWriteRendering().WriteWithOptions(&buf, tt.opts)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, buf.String(), tt.name)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	assert.Equal(t, 9, buildWriteRendering().Width())
+	assert.Equal(t, 0, NewEmptyRendering().Width())
+}
+
+func TestLine(t *testing.T) {
+	r := buildWriteRendering()
+	assert.Equal(t, 3, r.Height())
+	for i, expected := range []string{"   bazbaz", "bar", "  foo "} {
+		assert.Equal(t, expected, r.Line(i))
+	}
+	assert.Panics(t, func() { r.Line(3) })
+}
+
+// failingWriter accepts a limited number of writes and fails afterwards.
+type failingWriter struct {
+	writesLeft int
+	written    bytes.Buffer
+}
+
+var errWriteFailed = errors.New("write failed")
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	if fw.writesLeft == 0 {
+		return 0, errWriteFailed
+	}
+	fw.writesLeft--
+	return fw.written.Write(p)
+}
+
+func TestWriteToPropagatesErrors(t *testing.T) {
+	fw := &failingWriter{writesLeft: 1}
+	n, err := buildWriteRendering().WriteTo(fw)
+
+	assert.ErrorIs(t, err, errWriteFailed)
+	assert.Equal(t, "   bazbaz\n", fw.written.String())
+	assert.Equal(t, int64(10), n)
+}
@@ -0,0 +1,92 @@
+// Package syntax holds what the parsers of the text formats share: positions in the input, syntax errors,
+// and a scanner that reads the input rune by rune.
+package syntax
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Position is a location in the parsed input. Both the line and the column start at 1, the column counts runes, not bytes.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Error describes a problem with the parsed input and its position.
+type Error struct {
+	Pos Position
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Pos.Line, e.Pos.Column, e.Msg)
+}
+
+// Scanner reads the input rune by rune, keeping track of the position of the next rune.
+type Scanner struct {
+	Input  string
+	Offset int // of the next rune, in bytes
+	Pos    Position
+}
+
+// NewScanner returns a scanner at the beginning of the input.
+func NewScanner(input string) Scanner {
+	return Scanner{Input: input, Pos: Position{Line: 1, Column: 1}}
+}
+
+// AtEnd reports whether the whole input has been read.
+func (s *Scanner) AtEnd() bool {
+	return s.Offset >= len(s.Input)
+}
+
+// PeekRune returns the next rune without reading it, or utf8.RuneError at the end of the input.
+func (s *Scanner) PeekRune() rune {
+	r, _ := utf8.DecodeRuneInString(s.Input[s.Offset:])
+	return r
+}
+
+// NextRune reads the next rune and returns it, or utf8.RuneError at the end of the input.
+func (s *Scanner) NextRune() rune {
+	r, size := utf8.DecodeRuneInString(s.Input[s.Offset:])
+	s.Offset += size
+	if r == '\n' {
+		s.Pos.Line++
+		s.Pos.Column = 1
+	} else {
+		s.Pos.Column++
+	}
+	return r
+}
+
+// Accept reads the next rune if it's the given one, and reports whether it was.
+func (s *Scanner) Accept(r rune) bool {
+	if s.AtEnd() || s.PeekRune() != r {
+		return false
+	}
+	s.NextRune()
+	return true
+}
+
+// ReadQuoted reads the rest of a double-quoted string with the Go (and JSON) escapes. The opening quote, at the given offset
+// and position, has already been read. It returns the text of the string, with the quotes, and its unquoted value.
+// Errors are reported at the position of the opening quote.
+func (s *Scanner) ReadQuoted(start int, pos Position) (text, value string, err error) {
+	for !s.AtEnd() {
+		switch s.NextRune() {
+		case '\\':
+			if !s.AtEnd() {
+				s.NextRune()
+			}
+		case '"':
+			text = s.Input[start:s.Offset]
+			value, err := strconv.Unquote(text)
+			if err != nil {
+				return "", "", &Error{Pos: pos, Msg: fmt.Sprintf("invalid quoted string %s", text)}
+			}
+			return text, value, nil
+		}
+	}
+	return "", "", &Error{Pos: pos, Msg: "unterminated quoted string"}
+}
@@ -0,0 +1,63 @@
+package syntax
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanner(t *testing.T) {
+	s := NewScanner("ab\nżc")
+	var runes []rune
+	var positions []Position
+	for !s.AtEnd() {
+		positions = append(positions, s.Pos)
+		r := s.PeekRune()
+		assert.Equal(t, r, s.NextRune())
+		runes = append(runes, r)
+	}
+
+	assert.Equal(t, []rune("ab\nżc"), runes)
+	assert.Equal(t, []Position{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {2, 2}}, positions)
+	assert.Equal(t, Position{2, 3}, s.Pos)
+	assert.Equal(t, utf8.RuneError, s.PeekRune())
+}
+
+func TestReadQuoted(t *testing.T) {
+	tests := []struct {
+		input    string
+		text     string
+		value    string
+		expected string
+	}{
+		{`"a b" c`, `"a b"`, "a b", ""},
+		{`"a\"b"`, `"a\"b"`, `a"b`, ""},
+		{`"\u0105"`, `"\u0105"`, "ą", ""},
+		{`"a\q"`, "", "", `1:1: invalid quoted string "a\q"`},
+		{`"abc`, "", "", "1:1: unterminated quoted string"},
+	}
+
+	for _, tt := range tests {
+		s := NewScanner(tt.input)
+		pos := s.Pos
+		assert.True(t, s.Accept('"'))
+		text, value, err := s.ReadQuoted(0, pos)
+		if tt.expected != "" {
+			assert.EqualError(t, err, tt.expected, tt.input)
+			continue
+		}
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.text, text)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, len(tt.text), s.Offset)
+		}
+	}
+	empty := NewScanner("")
+	assert.False(t, empty.Accept('"'))
+}
+
+func TestError(t *testing.T) {
+	err := &Error{Pos: Position{Line: 2, Column: 5}, Msg: `unexpected ")"`}
+	assert.EqualError(t, err, `2:5: unexpected ")"`)
+}
@@ -0,0 +1,20 @@
+// Package treetest holds helpers for the tests of the packages that build trees.
+package treetest
+
+import (
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Shape returns a compact, parenthesized description of the tree, e.g. "(+ a _)". A missing child is printed as "_".
+// Unlike sexpr.Format, it doesn't quote the values, so the expected trees of the tests are easier to read.
+func Shape(n *printer.Node) string {
+	if n == nil {
+		return "_"
+	}
+	if n.IsLeaf() {
+		return n.Value
+	}
+	return "(" + strings.Join([]string{n.Value, Shape(n.LeftChild), Shape(n.RightChild)}, " ") + ")"
+}
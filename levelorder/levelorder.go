@@ -0,0 +1,192 @@
+// Package levelorder converts between printer.Node trees and level-order arrays, as used by LeetCode: "[1,2,null,3,4]".
+//
+// The first element is the root. Then, level by level and from left to right, every present node takes the next two elements
+// as its left and right child. "null" (or "#") marks a missing child. Missing nodes have no children, so no elements are reserved for them.
+//
+// Elements are separated by commas and the surrounding brackets are optional. An element is either a bare value, such as 1 or foo,
+// or a double-quoted string using the Go (and JSON) escapes, e.g. "null" for a node with the value null.
+package levelorder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/syntax"
+)
+
+// Position is a location in the parsed input. Both the line and the column start at 1, the column counts runes, not bytes.
+type Position = syntax.Position
+
+// SyntaxError describes a problem with the parsed array and its position.
+type SyntaxError = syntax.Error
+
+// element is a single element of the array. A nil value stands for a missing node.
+type element struct {
+	value *string
+	pos   Position
+}
+
+// Parse parses a level-order array and returns its tree. Errors are of type *SyntaxError.
+func Parse(input string) (*printer.Node, error) {
+	elements, err := split(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) == 0 || elements[0].value == nil {
+		return nil, &SyntaxError{Pos: Position{Line: 1, Column: 1}, Msg: "missing root"}
+	}
+
+	root := &printer.Node{Value: *elements[0].value}
+	queue := []*printer.Node{root}
+	next := 1
+	for len(queue) > 0 && next < len(elements) {
+		parent := queue[0]
+		queue = queue[1:]
+
+		for _, child := range []**printer.Node{&parent.LeftChild, &parent.RightChild} {
+			if next == len(elements) {
+				break
+			}
+			if el := elements[next]; el.value != nil {
+				*child = &printer.Node{Value: *el.value}
+				queue = append(queue, *child)
+			}
+			next++
+		}
+	}
+
+	// Only trailing nulls may be left when all the present nodes have their children.
+	for i := next; i < len(elements); i++ {
+		if elements[i].value != nil {
+			return nil, &SyntaxError{Pos: elements[i].pos, Msg: fmt.Sprintf("element %d has no parent", i)}
+		}
+	}
+	return root, nil
+}
+
+// Format returns the level-order array of the tree, without the trailing nulls.
+func Format(root *printer.Node) string {
+	var elements []string
+	queue := []*printer.Node{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == nil {
+			elements = append(elements, "null")
+			continue
+		}
+		elements = append(elements, quote(node.Value))
+		queue = append(queue, node.LeftChild, node.RightChild)
+	}
+
+	for len(elements) > 0 && elements[len(elements)-1] == "null" {
+		elements = elements[:len(elements)-1]
+	}
+	return "[" + strings.Join(elements, ",") + "]"
+}
+
+// quote returns the value as a bare element, or as a quoted string if it would be read as something else.
+func quote(value string) string {
+	if value == "" || value == "null" || value == "#" || strings.IndexFunc(value, func(r rune) bool { return !isBareRune(r) || !unicode.IsPrint(r) }) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func isBareRune(r rune) bool {
+	return !unicode.IsSpace(r) && r != ',' && r != '[' && r != ']' && r != '"'
+}
+
+// split splits the input into elements.
+func split(input string) ([]element, error) {
+	s := &scanner{Scanner: syntax.NewScanner(input)}
+
+	s.skipSpace()
+	bracket := s.Pos
+	hasBracket := s.Accept('[')
+
+	var elements []element
+	for {
+		s.skipSpace()
+		if s.AtEnd() || (hasBracket && s.PeekRune() == ']') {
+			if len(elements) > 0 {
+				return nil, s.unexpected("an element")
+			}
+			break
+		}
+
+		el, err := s.element()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, el)
+
+		s.skipSpace()
+		if !s.Accept(',') {
+			break
+		}
+	}
+
+	if hasBracket && !s.Accept(']') {
+		if s.AtEnd() {
+			return nil, &SyntaxError{Pos: bracket, Msg: `unclosed "["`}
+		}
+		return nil, s.unexpected(`"," or "]"`)
+	}
+	s.skipSpace()
+	if !s.AtEnd() {
+		return nil, s.unexpected(`","`)
+	}
+	return elements, nil
+}
+
+// scanner reads the elements of the array.
+type scanner struct {
+	syntax.Scanner
+}
+
+func (s *scanner) skipSpace() {
+	for !s.AtEnd() && unicode.IsSpace(s.PeekRune()) {
+		s.NextRune()
+	}
+}
+
+func (s *scanner) unexpected(expected string) error {
+	found := "end of input"
+	if !s.AtEnd() {
+		found = fmt.Sprintf("%q", s.PeekRune())
+	}
+	return &SyntaxError{Pos: s.Pos, Msg: fmt.Sprintf("unexpected %s, expected %s", found, expected)}
+}
+
+// element reads a bare or quoted element.
+func (s *scanner) element() (element, error) {
+	pos := s.Pos
+	start := s.Offset
+
+	if s.Accept('"') {
+		_, value, err := s.ReadQuoted(start, pos)
+		if err != nil {
+			return element{}, err
+		}
+		if value == "" {
+			return element{}, &SyntaxError{Pos: pos, Msg: "empty value"}
+		}
+		return element{value: &value, pos: pos}, nil
+	}
+
+	for !s.AtEnd() && isBareRune(s.PeekRune()) {
+		s.NextRune()
+	}
+	value := s.Input[start:s.Offset]
+	switch value {
+	case "":
+		return element{}, s.unexpected("an element")
+	case "null", "#":
+		return element{pos: pos}, nil
+	}
+	return element{value: &value, pos: pos}, nil
+}
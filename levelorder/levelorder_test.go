@@ -0,0 +1,83 @@
+package levelorder
+
+import (
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1]", "1"},
+		{"1", "1"},
+		{"[1,2,null,3,4]", "(1 (2 3 4))"},
+		{"[1,#,2,3]", "(1 () (2 3))"},
+		{"[5,4,8,11,null,13,4,7,2,null,null,null,1]", "(5 (4 (11 7 2)) (8 13 (4 () 1)))"},
+		{"[1,null,2,null,null]", "(1 () 2)"},
+		{" [ a , \"b c\" ,\n \"null\" ] ", `(a "b c" null)`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.input)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[]", `1:1: missing root`},
+		{"", `1:1: missing root`},
+		{"[null,1]", `1:1: missing root`},
+		{"[1,2", `1:1: unclosed "["`},
+		{"[1,,2]", `1:4: unexpected ',', expected an element`},
+		{"[1,2,]", `1:6: unexpected ']', expected an element`},
+		{"[1 2]", `1:4: unexpected '2', expected "," or "]"`},
+		{"[1,2] 3", `1:7: unexpected '3', expected ","`},
+		{"[1,null,null,2]", `1:14: element 3 has no parent`},
+		{`[1,""]`, `1:4: empty value`},
+		{`[1,"abc]`, `1:4: unterminated quoted string`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if assert.ErrorAs(t, err, &syntaxErr, tt.input) {
+			assert.Equal(t, tt.expected, err.Error(), tt.input)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		tree     string
+		expected string
+	}{
+		{"1", "[1]"},
+		{"(1 (2 3 4))", "[1,2,null,3,4]"},
+		{"(1 () (2 3))", "[1,null,2,3]"},
+		{"(5 (4 (11 7 2)) (8 13 (4 () 1)))", "[5,4,8,11,null,13,4,7,2,null,null,null,1]"},
+		{`(a "b c" (null "#"))`, `[a,"b c","null",null,null,"#"]`},
+	}
+
+	for _, tt := range tests {
+		tree, err := sexpr.Parse(tt.tree)
+		assert.NoError(t, err)
+
+		actual := Format(tree)
+		assert.Equal(t, tt.expected, actual, tt.tree)
+
+		parsed, err := Parse(actual)
+		if assert.NoError(t, err, actual) {
+			assert.Equal(t, tree, parsed, actual)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package modgraph reads Go module dependency graphs, as printed by "go mod graph", and shows them as trees.
+//
+// Every line of the input is an edge: a module and one of its requirements, e.g. "example.com/app golang.org/x/text@v0.3.7".
+// The main module has no version, and it's the module of the first line. The tree is rooted at the main module.
+//
+// The graph is not a tree: a module may be required by many modules, and requirements may even form cycles.
+// So the requirements of a module are shown only at its first occurrence (in depth-first order);
+// later occurrences are marked with the SeenMark and have no children.
+// Modules usually have more than two requirements, so the children are folded with printer.Group().
+package modgraph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// SeenMark is appended to the labels of modules whose requirements are shown at an earlier occurrence.
+const SeenMark = " (*)"
+
+// ElidedMark is appended to the labels of modules whose requirements are not shown because of Options.MaxDepth.
+const ElidedMark = " ..."
+
+// Options controls how the graph is shown.
+type Options struct {
+	// MaxDepth limits the levels of requirements below the main module. Zero means no limit.
+	// A module cut by the limit doesn't count as its first occurrence, so its requirements may be shown where it occurs higher up.
+	MaxDepth int
+	// HideVersions shows only the module paths. The versions of a module are still different nodes of the graph.
+	HideVersions bool
+}
+
+// Graph is a module dependency graph.
+type Graph struct {
+	// Root is the main module.
+	Root string
+	// Requirements maps a module ("path@version") to its requirements, in the order of the input.
+	Requirements map[string][]string
+}
+
+// Parse reads a graph in the format of "go mod graph". Empty lines are skipped.
+func Parse(r io.Reader) (*Graph, error) {
+	g := &Graph{Requirements: map[string][]string{}}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a module and its requirement, found %d fields", line, len(fields))
+		}
+		if g.Root == "" {
+			g.Root = fields[0]
+		}
+		g.Requirements[fields[0]] = append(g.Requirements[fields[0]], fields[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if g.Root == "" {
+		return nil, errors.New("empty graph")
+	}
+	return g, nil
+}
+
+// Tree converts the graph into a printer tree rooted at the main module.
+func (g *Graph) Tree(opts Options) *printer.Node {
+	return g.node(g.Root, 0, map[string]bool{}, &opts)
+}
+
+func (g *Graph) node(module string, depth int, seen map[string]bool, opts *Options) *printer.Node {
+	label := module
+	if opts.HideVersions {
+		label, _, _ = strings.Cut(module, "@")
+	}
+
+	requirements := g.Requirements[module]
+	switch {
+	case len(requirements) == 0:
+		return &printer.Node{Value: label}
+	case seen[module]:
+		return &printer.Node{Value: label + SeenMark}
+	case opts.MaxDepth > 0 && depth >= opts.MaxDepth:
+		return &printer.Node{Value: label + ElidedMark}
+	}
+
+	seen[module] = true
+	children := make([]*printer.Node, 0, len(requirements))
+	for _, requirement := range requirements {
+		children = append(children, g.node(requirement, depth+1, seen, opts))
+	}
+	return printer.Group(label, children...)
+}
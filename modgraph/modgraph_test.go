@@ -0,0 +1,58 @@
+package modgraph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+const graph = `example.com/app example.com/lib@v1.2.0
+example.com/app golang.org/x/text@v0.3.7
+example.com/app golang.org/x/tools@v0.1.0
+
+example.com/lib@v1.2.0 golang.org/x/text@v0.3.7
+golang.org/x/text@v0.3.7 golang.org/x/tools@v0.1.0
+golang.org/x/tools@v0.1.0 golang.org/x/text@v0.3.0
+golang.org/x/tools@v0.1.0 golang.org/x/mod@v0.4.0
+golang.org/x/mod@v0.4.0 golang.org/x/tools@v0.1.0
+`
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		expected string
+	}{
+		{"all", Options{},
+			`(example.com/app (... (example.com/lib@v1.2.0 (golang.org/x/text@v0.3.7 (golang.org/x/tools@v0.1.0 golang.org/x/text@v0.3.0 ` +
+				`(golang.org/x/mod@v0.4.0 "golang.org/x/tools@v0.1.0 (*)")))) "golang.org/x/text@v0.3.7 (*)") "golang.org/x/tools@v0.1.0 (*)")`},
+		{"depth", Options{MaxDepth: 1},
+			`(example.com/app (... "example.com/lib@v1.2.0 ..." "golang.org/x/text@v0.3.7 ...") "golang.org/x/tools@v0.1.0 ...")`},
+		{"without versions", Options{MaxDepth: 2, HideVersions: true},
+			`(example.com/app (... (example.com/lib "golang.org/x/text ...") (golang.org/x/text "golang.org/x/tools ...")) (golang.org/x/tools golang.org/x/text "golang.org/x/mod ..."))`},
+	}
+
+	g, err := Parse(strings.NewReader(graph))
+	assert.NoError(t, err)
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, sexpr.Format(g.Tree(tt.opts)), tt.name)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "empty graph"},
+		{"\n\n", "empty graph"},
+		{"a b@v1\nc\n", "line 2: expected a module and its requirement, found 1 fields"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.input))
+		assert.EqualError(t, err, tt.expected, tt.input)
+	}
+}
@@ -0,0 +1,135 @@
+package printer
+
+import "strings"
+
+// NaryNode is a node of a tree with any number of children, e.g. a directory hierarchy.
+// Unlike Group(), which folds such trees into binary ones, DrawNary() draws every node with all its children directly below it.
+type NaryNode struct {
+	Value    string
+	Children []*NaryNode
+}
+
+// DrawNary draws the tree with the root at the top and the children of every node side by side below it,
+// at least three spaces apart, and returns the lines of the picture (Fig. 1).
+// The parent is centered above its children and connected to them with a vertical line, a horizontal line,
+// and diagonal lines to the first and the last child (vertical ones to the other children).
+// The zero Style means StyleASCII; a style without the Vertical string uses "|".
+func DrawNary(root *NaryNode, style Style) []string {
+	if style == (Style{}) {
+		style = StyleASCII
+	}
+	if style.Vertical == "" {
+		style.Vertical = "|"
+	}
+	connectors := map[byte]string{'/': style.Left, '\\': style.Right, '_': style.Horizontal, '|': style.Vertical}
+
+	b := drawNary(root)
+	lines := make([]string, len(b.rows))
+	for i, row := range b.rows {
+		var sb strings.Builder
+		for _, c := range row {
+			if c.connector {
+				sb.WriteString(connectors[c.char])
+			} else {
+				sb.WriteByte(c.char)
+			}
+		}
+		lines[i] = strings.TrimRight(sb.String(), " ")
+	}
+	return lines
+}
+
+// naryCell is a column of a line of an n-ary drawing. Connector characters are replaced according to the style,
+// the characters of the values are not.
+type naryCell struct {
+	char      byte
+	connector bool
+}
+
+// naryBlock is the drawing of a subtree. The anchor is the column of the middle of the root value, where the connector from the parent ends.
+type naryBlock struct {
+	rows   [][]naryCell
+	width  int
+	anchor int
+}
+
+// put writes the text into the row at the column, extending the row with spaces if needed.
+func (b *naryBlock) put(row, column int, text string, connector bool) {
+	for len(b.rows) <= row {
+		b.rows = append(b.rows, nil)
+	}
+	for len(b.rows[row]) < column+len(text) {
+		b.rows[row] = append(b.rows[row], naryCell{char: ' '})
+	}
+	for i := 0; i < len(text); i++ {
+		b.rows[row][column+i] = naryCell{char: text[i], connector: connector}
+	}
+}
+
+func drawNary(n *NaryNode) *naryBlock {
+	if n == nil || n.Value == "" {
+		panic("nil or empty NaryNode")
+	}
+
+	var children []*naryBlock
+	for _, child := range n.Children {
+		if child != nil {
+			children = append(children, drawNary(child))
+		}
+	}
+	b := &naryBlock{width: len(n.Value), anchor: (len(n.Value) - 1) / 2}
+	if len(children) == 0 {
+		b.put(0, 0, n.Value, false)
+		return b
+	}
+
+	// Place the children side by side and center the parent above the anchors of the first and the last child.
+	offsets := make([]int, len(children))
+	for i := 1; i < len(children); i++ {
+		offsets[i] = offsets[i-1] + children[i-1].width + 3
+	}
+	first, last := children[0].anchor, offsets[len(children)-1]+children[len(children)-1].anchor
+	b.anchor = (first + last) / 2
+	shift := max(0, (len(n.Value)-1)/2-b.anchor) // a long value mustn't stick out on the left
+	b.anchor += shift
+	first += shift
+	last += shift
+	b.width = max(offsets[len(children)-1]+children[len(children)-1].width+shift, b.anchor-(len(n.Value)-1)/2+len(n.Value))
+
+	b.put(0, b.anchor-(len(n.Value)-1)/2, n.Value, false)
+	if len(children) == 1 {
+		b.put(1, b.anchor, "|", true)
+		b.put(2, b.anchor, "|", true)
+	} else {
+		b.put(1, first+2, strings.Repeat("_", last-first-3), true)
+		b.put(1, b.anchor, "|", true)
+		b.put(2, first+1, "/", true)
+		b.put(2, last-1, "\\", true)
+		for i := 1; i < len(children)-1; i++ {
+			b.put(2, offsets[i]+shift+children[i].anchor, "|", true)
+		}
+	}
+
+	for i, child := range children {
+		for j, row := range child.rows {
+			b.put(3+j, offsets[i]+shift+len(row), "", false)
+			copy(b.rows[3+j][offsets[i]+shift:], row)
+		}
+	}
+	return b
+}
+
+/*
+
+------------------------------------------------------------
+Fig. 1 - An n-ary tree
+
+          root/
+   _________|_________
+  /        |          \
+cmd/   internal/   README.md
+ |         |
+ |         |
+a.go    render/
+
+*/
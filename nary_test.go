@@ -0,0 +1,71 @@
+package printer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func nary(value string, children ...*NaryNode) *NaryNode {
+	return &NaryNode{Value: value, Children: children}
+}
+
+func naryText(lines []string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestDrawNary(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     *NaryNode
+		expected string
+	}{
+		{"leaf", nary("a"), "a\n"},
+		{"single child", nary("root", nary("a")), render.Nlnl(`
+root
+ |
+ |
+ a
+`)},
+		{"two children", nary("+", nary("1"), nary("2")), render.Nlnl(`
+  +
+  |
+ / \
+1   2
+`)},
+		{"three children", nary("root/", nary("cmd/", nary("a.go")), nary("internal/", nary("render/")), nary("README.md")), render.Nlnl(`
+          root/
+   _________|_________
+  /        |          \
+cmd/   internal/   README.md
+ |         |
+ |         |
+a.go    render/
+`)},
+		{"long parent", nary("a long value", nary("x"), nary("y")), render.Nlnl(`
+a long value
+     |
+    / \
+   x   y
+`)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, naryText(DrawNary(tt.root, Style{})), tt.name)
+	}
+}
+
+func TestDrawNaryStyle(t *testing.T) {
+	root := nary("a|b", nary("/"), nary("_"), nary(`\`))
+
+	actual := naryText(DrawNary(root, StyleUnicode))
+	expected := render.Nlnl(`
+   a|b
+  ──│──
+ ╱  │  ╲
+/   _   \
+`)
+	assert.Equal(t, expected, actual)
+}
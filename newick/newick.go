@@ -0,0 +1,251 @@
+// Package newick converts between printer.Node trees and the Newick format, e.g. "((A,B)AB,C)root;".
+//
+// The children of a node are listed in parentheses before its label. A node may have one or two children: the only child becomes the left child.
+// Labels are either unquoted, with underscores standing for spaces, or quoted with single quotes, where two single quotes stand for one.
+// A branch length becomes a part of the value: "A:0.1" results in the value "A:0.1", and it's written back the same way,
+// so a value ending with a colon and a number is always written as a label with a branch length.
+// Nodes without a label get the Unnamed value. Comments in square brackets are skipped.
+package newick
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/syntax"
+)
+
+// Unnamed is the value of nodes that have no label in the Newick text. Nodes with this value are written without a label.
+const Unnamed = "?"
+
+// Position is a location in the parsed input. Both the line and the column start at 1, the column counts runes, not bytes.
+type Position = syntax.Position
+
+// SyntaxError describes a problem with the parsed Newick text and its position.
+type SyntaxError = syntax.Error
+
+// Parse parses a single Newick tree terminated with a semicolon. Errors are of type *SyntaxError.
+func Parse(input string) (*printer.Node, error) {
+	p := &parser{Scanner: syntax.NewScanner(input)}
+	p.skipSpace()
+
+	root, err := p.parseSubtree()
+	if err != nil {
+		return nil, err
+	}
+	if p.PeekRune() != ';' {
+		return nil, p.unexpected(`";"`)
+	}
+	p.NextRune()
+	p.skipSpace()
+	if !p.AtEnd() {
+		return nil, p.unexpected("end of input")
+	}
+	return root, nil
+}
+
+// Format returns the Newick text of the tree, terminated with a semicolon.
+// Returns an error if the tree contains a node with just the right child, which can't be expressed in Newick.
+func Format(root *printer.Node) (string, error) {
+	var sb strings.Builder
+	if err := format(&sb, root); err != nil {
+		return "", err
+	}
+	sb.WriteString(";")
+	return sb.String(), nil
+}
+
+func format(sb *strings.Builder, n *printer.Node) error {
+	if !n.IsLeaf() {
+		if n.LeftChild == nil {
+			return fmt.Errorf("node %q has only the right child", n.Value)
+		}
+		sb.WriteString("(")
+		if err := format(sb, n.LeftChild); err != nil {
+			return err
+		}
+		if n.RightChild != nil {
+			sb.WriteString(",")
+			if err := format(sb, n.RightChild); err != nil {
+				return err
+			}
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(formatLabel(n.Value))
+	return nil
+}
+
+// formatLabel returns the label of the value followed by the branch length, if the value ends with one (see parseLabel).
+func formatLabel(value string) string {
+	label, length := value, ""
+	if i := strings.LastIndexByte(value, ':'); i >= 0 {
+		// The length must be read back whole, so it can't contain the runes ending an unquoted label.
+		_, err := strconv.ParseFloat(value[i+1:], 64)
+		if err == nil && strings.TrimFunc(value[i+1:], isLabelRune) == "" {
+			label, length = value[:i], value[i:]
+		}
+	}
+	if label == Unnamed {
+		return length
+	}
+	return quote(label) + length
+}
+
+// quote returns the label as is, if it can be written as an unquoted label, or in single quotes otherwise.
+func quote(value string) string {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool { return !isLabelRune(r) || r == '_' || !unicode.IsPrint(r) }) < 0 {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// isLabelRune reports whether the rune may appear in an unquoted label.
+func isLabelRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune("()[]':;,", r)
+}
+
+// parser is a recursive descent parser working directly on the input, keeping track of the current position.
+type parser struct {
+	syntax.Scanner
+}
+
+func (p *parser) unexpected(expected string) error {
+	found := "end of input"
+	if !p.AtEnd() {
+		found = fmt.Sprintf("%q", p.PeekRune())
+	}
+	return &SyntaxError{Pos: p.Pos, Msg: fmt.Sprintf("unexpected %s, expected %s", found, expected)}
+}
+
+// parseSubtree parses an optional list of children followed by an optional label and branch length.
+func (p *parser) parseSubtree() (*printer.Node, error) {
+	var children []*printer.Node
+	start := p.Pos
+	if p.PeekRune() == '(' {
+		p.NextRune()
+		p.skipSpace()
+		for {
+			childPos := p.Pos
+			child, err := p.parseSubtree()
+			if err != nil {
+				return nil, err
+			}
+			if len(children) == 2 {
+				return nil, &SyntaxError{Pos: childPos, Msg: "node has more than two children"}
+			}
+			children = append(children, child)
+
+			if p.PeekRune() == ')' {
+				p.NextRune()
+				p.skipSpace()
+				break
+			}
+			if p.AtEnd() {
+				return nil, &SyntaxError{Pos: start, Msg: `unclosed "("`}
+			}
+			if p.PeekRune() != ',' {
+				return nil, p.unexpected(`"," or ")"`)
+			}
+			p.NextRune()
+			p.skipSpace()
+		}
+	}
+
+	value, err := p.parseLabel()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &printer.Node{Value: value}
+	switch len(children) {
+	case 2:
+		node.RightChild = children[1]
+		fallthrough
+	case 1:
+		node.LeftChild = children[0]
+	}
+	return node, nil
+}
+
+// parseLabel parses an optional label followed by an optional branch length. Returns Unnamed for a missing label.
+func (p *parser) parseLabel() (string, error) {
+	var label string
+	if p.PeekRune() == '\'' {
+		quoted, err := p.readQuoted()
+		if err != nil {
+			return "", err
+		}
+		label = quoted
+	} else {
+		label = strings.ReplaceAll(p.readWhile(isLabelRune), "_", " ")
+	}
+	p.skipSpace()
+
+	if label == "" {
+		label = Unnamed
+	}
+
+	if p.PeekRune() == ':' {
+		p.NextRune()
+		p.skipSpace()
+		lengthPos := p.Pos
+		length := p.readWhile(isLabelRune)
+		if _, err := strconv.ParseFloat(length, 64); err != nil {
+			return "", &SyntaxError{Pos: lengthPos, Msg: fmt.Sprintf("invalid branch length %q", length)}
+		}
+		p.skipSpace()
+		label += ":" + length
+	}
+	return label, nil
+}
+
+// readQuoted reads a label in single quotes, the current rune is the opening quote.
+func (p *parser) readQuoted() (string, error) {
+	start := p.Pos
+	p.NextRune()
+
+	var sb strings.Builder
+	for !p.AtEnd() {
+		r := p.NextRune()
+		if r != '\'' {
+			sb.WriteRune(r)
+			continue
+		}
+		if p.PeekRune() != '\'' {
+			return sb.String(), nil
+		}
+		p.NextRune()
+		sb.WriteRune('\'')
+	}
+	return "", &SyntaxError{Pos: start, Msg: "unterminated quoted label"}
+}
+
+func (p *parser) readWhile(accept func(rune) bool) string {
+	start := p.Offset
+	for !p.AtEnd() && accept(p.PeekRune()) {
+		p.NextRune()
+	}
+	return p.Input[start:p.Offset]
+}
+
+// skipSpace skips whitespace and comments in square brackets.
+func (p *parser) skipSpace() {
+	for !p.AtEnd() {
+		r := p.PeekRune()
+		switch {
+		case r == '[':
+			for !p.AtEnd() {
+				if p.NextRune() == ']' {
+					break
+				}
+			}
+		case unicode.IsSpace(r):
+			p.NextRune()
+		default:
+			return
+		}
+	}
+}
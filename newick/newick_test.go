@@ -0,0 +1,98 @@
+package newick
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"A;", "A"},
+		{"((A,B),C);", "(? (? A B) C)"},
+		{"((A,B)AB,C)root;", "(root (AB A B) C)"},
+		{"(A)B;", "(B A)"},
+		{"(,);", "(? ? ?)"},
+		{"(Homo_sapiens:0.1,'Pan''s troglodytes':2e-3):0.5;", `(?:0.5 "Homo sapiens:0.1" "Pan's troglodytes:2e-3")`},
+		{"( A [a comment] ,\n  B ) [another one] root ;\n", "(root A B)"},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.input)
+		}
+	}
+}
+
+func TestBranchLengthsRoundTrip(t *testing.T) {
+	for _, input := range []string{"((A:0.1,B:0.2):0.3,C);", "(A:1e-3,(B,'C d':4):0.5)root:0;"} {
+		tree, err := Parse(input)
+		if assert.NoError(t, err, input) {
+			actual, err := Format(tree)
+			assert.NoError(t, err, input)
+			assert.Equal(t, input, actual)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"(A,B)", `1:6: unexpected end of input, expected ";"`},
+		{"((A,B),C", `1:1: unclosed "("`},
+		{"(A,B,C);", `1:6: node has more than two children`},
+		{"(A,\n B C);", `2:4: unexpected 'C', expected "," or ")"`},
+		{"(A:x,B);", `1:4: invalid branch length "x"`},
+		{"('A,B);", `1:2: unterminated quoted label`},
+		{"A; B;", `1:4: unexpected 'B', expected end of input`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if assert.ErrorAs(t, err, &syntaxErr, tt.input) {
+			assert.Equal(t, tt.expected, err.Error(), tt.input)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		tree     string
+		expected string
+	}{
+		{"A", "A;"},
+		{"(? (? A B) C)", "((A,B),C);"},
+		{"(root (AB A B) C)", "((A,B)AB,C)root;"},
+		{"(- x)", "(x)-;"},
+		{`("a b" "it's" "x:1")`, "('it''s',x:1)'a b';"},
+		{`(?:0.3 (?:0.5 "A:0.1" "B b:2e-3") "C:x:1")`, "((A:0.1,'B b':2e-3):0.5,'C:x':1):0.3;"},
+		{`(? "x:" y:Inf)`, "('x:',y:Inf);"},
+	}
+
+	for _, tt := range tests {
+		tree, err := sexpr.Parse(tt.tree)
+		assert.NoError(t, err)
+
+		actual, err := Format(tree)
+		if assert.NoError(t, err, tt.tree) {
+			assert.Equal(t, tt.expected, actual, tt.tree)
+		}
+
+		parsed, err := Parse(actual)
+		if assert.NoError(t, err, actual) {
+			assert.Equal(t, tree, parsed, actual)
+		}
+	}
+
+	_, err := Format(&printer.Node{Value: "neg", RightChild: &printer.Node{Value: "x"}})
+	assert.EqualError(t, err, `node "neg" has only the right child`)
+}
@@ -0,0 +1,187 @@
+// Package outline converts between printer.Node trees and indented text outlines:
+//
+//	+
+//	  L: 1
+//	  R: *
+//	    2
+//	    3
+//
+// Every line holds a single value, and the children of a node are indented by one level more than the node.
+// The indentation unit is detected from the first indented line: either a tab, or any number of spaces.
+// The "L: " and "R: " markers, which are optional, put a child on the given side.
+// Unmarked children take the remaining sides, left first. Empty lines are skipped.
+// A value starting with a double quote is a Go quoted string, e.g. "\"L: x\"" for a root value that starts with a marker.
+package outline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/syntax"
+)
+
+// Markers of the children sides.
+const (
+	LeftMarker  = "L: "
+	RightMarker = "R: "
+)
+
+// Position is a location in the parsed input. Both the line and the column start at 1, the column counts runes, not bytes.
+type Position = syntax.Position
+
+// SyntaxError describes a problem with the parsed outline and its position.
+type SyntaxError = syntax.Error
+
+// Parse parses an outline and returns its tree. Errors are of type *SyntaxError.
+func Parse(text string) (*printer.Node, error) {
+	p := &parser{}
+	var root *printer.Node
+	// path holds the nodes from the root to the previous line, path[i] is at the level i.
+	var path []*printer.Node
+
+	for i, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lineNo := i + 1
+		content := strings.TrimLeft(line, " \t")
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		level, err := p.level(line[:len(line)-len(content)], lineNo)
+		if err != nil {
+			return nil, err
+		}
+		column := utf8.RuneCountInString(line[:len(line)-len(content)]) + 1
+
+		switch {
+		case level == 0 && root != nil:
+			return nil, &SyntaxError{Pos: Position{Line: lineNo, Column: column}, Msg: "more than one root"}
+		case level > 0 && root == nil:
+			return nil, &SyntaxError{Pos: Position{Line: lineNo, Column: column}, Msg: "the root must not be indented"}
+		case level > len(path):
+			return nil, &SyntaxError{Pos: Position{Line: lineNo, Column: column}, Msg: fmt.Sprintf("indented by %d levels, expected at most %d", level, len(path))}
+		}
+
+		side, value := "", content
+		if strings.HasPrefix(value, LeftMarker) || strings.HasPrefix(value, RightMarker) {
+			side, value = value[:1], strings.TrimLeft(value[len(LeftMarker):], " ")
+		}
+		value = strings.TrimRight(value, " \t")
+		if value == "" {
+			return nil, &SyntaxError{Pos: Position{Line: lineNo, Column: column}, Msg: "empty value"}
+		}
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil || unquoted == "" {
+				valueColumn := column + utf8.RuneCountInString(content[:strings.Index(content, value)])
+				return nil, &SyntaxError{Pos: Position{Line: lineNo, Column: valueColumn}, Msg: "invalid quoted value " + value}
+			}
+			value = unquoted
+		}
+
+		node := &printer.Node{Value: value}
+		if level == 0 {
+			if side != "" {
+				return nil, &SyntaxError{Pos: Position{Line: lineNo, Column: column}, Msg: "the root can't have a side marker"}
+			}
+			root = node
+		} else if err := attach(path[level-1], node, side); err != nil {
+			return nil, &SyntaxError{Pos: Position{Line: lineNo, Column: column}, Msg: err.Error()}
+		}
+		path = append(path[:level], node)
+	}
+
+	if root == nil {
+		return nil, &SyntaxError{Pos: Position{Line: 1, Column: 1}, Msg: "empty outline"}
+	}
+	return root, nil
+}
+
+// attach adds the child to the parent on the given side ("L", "R" or "" for the first free side).
+func attach(parent, child *printer.Node, side string) error {
+	if side == "" {
+		if parent.LeftChild == nil {
+			side = "L"
+		} else {
+			side = "R"
+		}
+	}
+
+	slot, name := &parent.LeftChild, "left"
+	if side == "R" {
+		slot, name = &parent.RightChild, "right"
+	}
+	if *slot != nil {
+		if parent.LeftChild != nil && parent.RightChild != nil {
+			return fmt.Errorf("%q already has two children", parent.Value)
+		}
+		return fmt.Errorf("%q already has the %s child %q", parent.Value, name, (*slot).Value)
+	}
+	*slot = child
+	return nil
+}
+
+// parser keeps the indentation unit, detected from the first indented line.
+type parser struct {
+	unit string
+}
+
+// level returns the nesting level of the given indentation.
+func (p *parser) level(indent string, lineNo int) (int, error) {
+	if indent == "" {
+		return 0, nil
+	}
+	if strings.Contains(indent, " ") && strings.Contains(indent, "\t") {
+		return 0, &SyntaxError{Pos: Position{Line: lineNo, Column: 1}, Msg: "indentation mixes tabs and spaces"}
+	}
+	if p.unit == "" {
+		p.unit = indent
+	}
+	if indent[0] != p.unit[0] {
+		return 0, &SyntaxError{Pos: Position{Line: lineNo, Column: 1}, Msg: fmt.Sprintf("indented with %s, but the outline is indented with %s", describe(indent), describe(p.unit))}
+	}
+	if len(indent)%len(p.unit) != 0 {
+		return 0, &SyntaxError{Pos: Position{Line: lineNo, Column: 1}, Msg: fmt.Sprintf("indentation of %s is not a multiple of %s", describe(indent), describe(p.unit))}
+	}
+	return len(indent) / len(p.unit), nil
+}
+
+func describe(indent string) string {
+	name := "space"
+	if indent[0] == '\t' {
+		name = "tab"
+	}
+	if len(indent) == 1 {
+		return "1 " + name
+	}
+	return fmt.Sprintf("%d %ss", len(indent), name)
+}
+
+// Format returns the outline of the tree, indented with two spaces per level. Children are always preceded by their side markers.
+// Values that would be read back differently are quoted: values with surrounding spaces, a leading double quote or non-printable
+// characters, and a root value starting with a marker.
+func Format(root *printer.Node) string {
+	var sb strings.Builder
+	format(&sb, root, "", "")
+	return sb.String()
+}
+
+func format(sb *strings.Builder, n *printer.Node, indent, marker string) {
+	if n == nil {
+		return
+	}
+	sb.WriteString(indent + marker + formatValue(n.Value, marker == "") + "\n")
+	format(sb, n.LeftChild, indent+"  ", LeftMarker)
+	format(sb, n.RightChild, indent+"  ", RightMarker)
+}
+
+// formatValue returns the value as written in the outline: as is, unless it has to be quoted to be read back the same.
+func formatValue(value string, root bool) string {
+	if value != strings.TrimSpace(value) || strings.HasPrefix(value, `"`) || strings.IndexFunc(value, func(r rune) bool { return !strconv.IsPrint(r) }) >= 0 ||
+		root && (strings.HasPrefix(value, LeftMarker) || strings.HasPrefix(value, RightMarker)) {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,107 @@
+package outline
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/render"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x", "x"},
+		{"+\n  1\n  2\n", "(+ 1 2)"},
+		{"+\n  L: 1\n  R: *\n    2\n    3\n", "(+ 1 (* 2 3))"},
+		{"neg\n  R: x\n", "(neg () x)"},
+		{"-\n  R: b\n  a\n", "(- a b)"},
+		{"\n+\n\n\t1\n\t*\n\t\tx\n\n", "(+ 1 (* x))"},
+		{"+\n    L: a b \n    R:    c\n", `(+ "a b" c)`},
+		{"root\n  L: L: x\n", `(root "L: x")`},
+		{"\"L: x\"\n  R: \" y \"\n", `("L: x" () " y ")`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.input)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"\n  \n", `1:1: empty outline`},
+		{"  +", `1:3: the root must not be indented`},
+		{"+\n-", `2:1: more than one root`},
+		{"+\n  1\n      2", `3:7: indented by 3 levels, expected at most 2`},
+		{"+\n  1\n   2", `3:1: indentation of 3 spaces is not a multiple of 2 spaces`},
+		{"+\n  1\n\t2", `3:1: indented with 1 tab, but the outline is indented with 2 spaces`},
+		{"+\n \t1", `2:1: indentation mixes tabs and spaces`},
+		{"+\n  1\n  2\n  3", `4:3: "+" already has two children`},
+		{"+\n  L: 1\n  L: 2", `3:3: "+" already has the left child "1"`},
+		{"+\n  R: 1\n  R: 2", `3:3: "+" already has the right child "1"`},
+		{"L: +", `1:1: the root can't have a side marker`},
+		{"+\n  L: ", `2:3: empty value`},
+		{"+\n  L:  \"x", `2:7: invalid quoted value "x`},
+		{"\"\"", `1:1: invalid quoted value ""`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if assert.ErrorAs(t, err, &syntaxErr, tt.input) {
+			assert.Equal(t, tt.expected, err.Error(), tt.input)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tree, err := sexpr.Parse(`(+ (neg () x) (* "L: 2" 3))`)
+	assert.NoError(t, err)
+
+	actual := Format(tree)
+	expected := render.Nlnl(`
++
+  L: neg
+    R: x
+  R: *
+    L: L: 2
+    R: 3
+`)
+	assert.Equal(t, expected, actual)
+
+	parsed, err := Parse(actual)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tree, parsed)
+	}
+}
+
+func TestFormatQuotedValues(t *testing.T) {
+	tests := []struct {
+		tree     *printer.Node
+		expected string
+	}{
+		{&printer.Node{Value: "L: x"}, "\"L: x\"\n"},
+		{&printer.Node{Value: "R: x", RightChild: &printer.Node{Value: "R: y"}}, "\"R: x\"\n  R: R: y\n"},
+		{&printer.Node{Value: "a", LeftChild: &printer.Node{Value: " b"}, RightChild: &printer.Node{Value: `"c"`}}, "a\n  L: \" b\"\n  R: \"\\\"c\\\"\"\n"},
+		{&printer.Node{Value: "a\tb"}, "\"a\\tb\"\n"},
+	}
+
+	for _, tt := range tests {
+		actual := Format(tt.tree)
+		assert.Equal(t, tt.expected, actual)
+
+		parsed, err := Parse(actual)
+		if assert.NoError(t, err, actual) {
+			assert.Equal(t, tt.tree, parsed, actual)
+		}
+	}
+}
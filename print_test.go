@@ -1,6 +1,8 @@
 package printer
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/ZupkaPomidorowa/print-tree/internal/render"
@@ -135,6 +137,116 @@ left         right    left         right
 	assert.Equal(t, expected, actual.String())
 }
 
+// The children of the root need exactly the minimal distance here, which used to place the two middle leaves only two spaces apart.
+func TestPrintTreeChildrenAtMinimalDistance(t *testing.T) {
+	root := &Node{
+		Value: "4444",
+		LeftChild: &Node{
+			Value:      "1",
+			LeftChild:  &Node{Value: "333"},
+			RightChild: &Node{Value: "1"},
+		},
+		RightChild: &Node{
+			Value:      "4444",
+			LeftChild:  &Node{Value: "1"},
+			RightChild: &Node{Value: "22"},
+		},
+	}
+
+	actual := PrintTree(root)
+	expected := render.Nlnl(`
+           4444
+          /    \
+        _/      \_
+       /          \
+      1            4444
+     / \          /    \
+    /   \        /      \
+   /     \      /        \
+333       1    1          22
+`)
+	//           ^ three spaces up there
+	assert.Equal(t, expected, actual.String())
+}
+
+func TestPrintTreeLeftChildOnly(t *testing.T) {
+	root := &Node{
+		Value: "-",
+		LeftChild: &Node{
+			Value:      "*",
+			LeftChild:  &Node{Value: "x"},
+			RightChild: &Node{Value: "y"},
+		},
+	}
+
+	actual := PrintTree(root)
+	expected := render.Nlnl(`
+        -
+       /
+      /
+     /
+    *
+   / \
+  /   \
+ /     \
+x       y
+`)
+	assert.Equal(t, expected, actual.String())
+}
+
+func TestPrintTreeRightChildOnly(t *testing.T) {
+	root := &Node{
+		Value: "neg",
+		RightChild: &Node{
+			Value:      "*",
+			LeftChild:  &Node{Value: "x"},
+			RightChild: &Node{Value: "y"},
+		},
+	}
+
+	actual := PrintTree(root)
+	expected := render.Nlnl(`
+neg
+   \
+    \
+     \
+      *
+     / \
+    /   \
+   /     \
+  x       y
+`)
+	assert.Equal(t, expected, actual.String())
+}
+
+func TestPrintTreeSingleChildrenInside(t *testing.T) {
+	root := &Node{
+		Value: "root",
+		LeftChild: &Node{
+			Value:      "a",
+			RightChild: &Node{Value: "b"},
+		},
+		RightChild: &Node{
+			Value:     "c",
+			LeftChild: &Node{Value: "d"},
+		},
+	}
+
+	actual := PrintTree(root)
+	expected := render.Nlnl(`
+     root
+    /    \
+  _/      \_
+ /          \
+a            c
+ \          /
+  \        /
+   \      /
+    b    d
+`)
+	assert.Equal(t, expected, actual.String())
+}
+
 func buildTree(rootNodeVal string) *Node {
 	leftLeaf := &Node{
 		Value: "left",
@@ -160,3 +272,14 @@ func buildTree(rootNodeVal string) *Node {
 		RightChild: rightChild,
 	}
 }
+
+func TestFprint(t *testing.T) {
+	tree := buildTree("root")
+
+	var buf bytes.Buffer
+	n, err := Fprint(&buf, tree, WriteOptions{LineEnding: "\r\n"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, strings.ReplaceAll(PrintTree(tree).String(), "\n", "\r\n"), buf.String())
+	assert.Equal(t, int64(buf.Len()), n)
+}
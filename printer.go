@@ -1,13 +1,25 @@
 package printer
 
 import (
+	"io"
+
 	"github.com/ZupkaPomidorowa/print-tree/internal/render"
 )
 
+// WriteOptions controls the line endings and trailing whitespace of Fprint() output.
+type WriteOptions = render.WriteOptions
+
+// Node is a node of a binary tree. A node without children is a leaf.
+//
+// The json and yaml tags define the document schema of a tree, also used by the document package:
+//
+//	{"value": "+", "left": {"value": "1"}, "right": {"value": "2"}}
+//
+// The "left" and "right" fields are optional, "value" is required and must not be empty.
 type Node struct {
-	Value      string
-	LeftChild  *Node
-	RightChild *Node
+	Value      string `json:"value" yaml:"value"`
+	LeftChild  *Node  `json:"left,omitempty" yaml:"left,omitempty"`
+	RightChild *Node  `json:"right,omitempty" yaml:"right,omitempty"`
 }
 
 func (n *Node) IsLeaf() bool {
@@ -28,14 +40,19 @@ func PrintTree(curNode *Node) *render.Rendering {
 	}
 
 	// Print the left child. This will determine the position of the parent and the right child.
-	leftChildRendering := PrintTree(curNode.LeftChild)
-
-	// Rev-normalize the left child, because the top row value starts at the zero index, and we want the top value to END at the zero index.
-	// This ensures that connector lines will be drawn correctly.
-	leftChildRendering.NormalizeOffsetsRev()
+	// A missing child is represented by an empty rendering: it takes no space, and its connector is not drawn.
+	leftChildRendering := render.NewEmptyRendering()
+	if curNode.LeftChild != nil {
+		// Rev-normalize the left child, because the top row value starts at the zero index, and we want the top value to END at the zero index.
+		// This ensures that connector lines will be drawn correctly.
+		leftChildRendering = PrintTree(curNode.LeftChild).NormalizeOffsetsRev()
+	}
 
 	// Print the right child.
-	rightChildRendering := PrintTree(curNode.RightChild).NormalizeOffsets()
+	rightChildRendering := render.NewEmptyRendering()
+	if curNode.RightChild != nil {
+		rightChildRendering = PrintTree(curNode.RightChild).NormalizeOffsets()
+	}
 
 	// Calculate the distance between the children. The distance corresponds to the number of characters between the zero index of the left child and the zero index of the right child,
 	// when both child are placed as close as possible (touching but not overlapping).
@@ -45,9 +62,11 @@ func PrintTree(curNode *Node) *render.Rendering {
 	minPossibleDistance := 6 + len(curNode.Value) + 1
 
 	var distance int
-	if minPossibleDistance >= requiredChildDistance {
+	// The distance is counted from the zero index of the left child, while its value ends at the index -1, hence the -1.
+	// Comparing the already decremented distance makes sure that the children never end up closer than the required distance.
+	if minPossibleDistance-1 >= requiredChildDistance {
 		//just join the children using minimalDistance
-		distance = minPossibleDistance - 1 //TODO: why -1?
+		distance = minPossibleDistance - 1
 	} else {
 		// children require more space than minPossibleDistance. Join the children using 3 additiional spaces.
 		distance = requiredChildDistance
@@ -60,26 +79,55 @@ func PrintTree(curNode *Node) *render.Rendering {
 
 	result := render.JoinRenderings(leftChildRendering, rightChildRendering, distance)
 
+	hasLeft := curNode.LeftChild != nil
+	hasRight := curNode.RightChild != nil
+
 	// Print the connectors. The connectors span three rows: lower connector row (closest to children), middle connector row and upper connector row (closest to parent).
+	// If one of the children is missing, only the half of every connector row that leads to the existing child is printed (Fig. 5).
 	// lower row:
 	lowerRowSpacesCnt := (distance - 2) // 2 is for the two slashes
-	result.AddOnTop("/" + render.Spaces(lowerRowSpacesCnt) + "\\")
+	addConnectorRow(result, hasLeft, hasRight, "/", render.Spaces(lowerRowSpacesCnt), "\\", 0)
 
 	// middle row:
 	middleRowSpacesCnt := len(curNode.Value) + 2 // 2 is for the two slashes
 	middleRowUnderscoreCount := (lowerRowSpacesCnt-middleRowSpacesCnt)/2 - 1
-	result.AddOnTop(render.Underscores(middleRowUnderscoreCount) + "/" + render.Spaces(len(curNode.Value)+2) + "\\" + render.Underscores(middleRowUnderscoreCount)).ShiftTopBy(1)
+	addConnectorRow(result, hasLeft, hasRight, render.Underscores(middleRowUnderscoreCount)+"/", render.Spaces(len(curNode.Value)+2), "\\"+render.Underscores(middleRowUnderscoreCount), 1)
 
 	// upper row:
 	shift := ((distance - len(curNode.Value)) / 2) - 1
-	result.AddOnTop("/" + render.Spaces(len(curNode.Value)) + "\\").ShiftTopBy(shift)
+	addConnectorRow(result, hasLeft, hasRight, "/", render.Spaces(len(curNode.Value)), "\\", shift)
 
 	// parent value
 	result.AddOnTop(curNode.Value).ShiftTopBy(shift + 1)
 
+	if !hasLeft {
+		// Without the left child nothing is printed at the zero index, so the left edge has to be moved to the leftmost row.
+		result.TrimLeft()
+	}
+
 	return result
 }
 
+// addConnectorRow adds a connector row made of the left part, the gap and the right part on top of the rendering.
+// The row starts at the given offset. A part leading to a missing child is skipped and the rest of the row is shifted so that it keeps its column.
+func addConnectorRow(result *render.Rendering, hasLeft, hasRight bool, left, gap, right string, offset int) {
+	switch {
+	case hasLeft && hasRight:
+		result.AddOnTop(left, gap, right).ShiftTopBy(offset)
+	case hasLeft:
+		result.AddOnTop(left).ShiftTopBy(offset)
+	default:
+		result.AddOnTop(right).ShiftTopBy(offset + len(left) + len(gap))
+	}
+}
+
+// Fprint prints the given tree to w.
+// The rendering is streamed row by row, so very wide trees are never concatenated into a single string.
+// Returns the number of bytes written and the first write error, if any.
+func Fprint(w io.Writer, root *Node, opts WriteOptions) (int64, error) {
+	return PrintTree(root).WriteWithOptions(w, opts)
+}
+
 /*
 
 ------------------------------------------------------------
@@ -140,4 +188,18 @@ Fig. 4
      /     \           /       \
     2       345    6789         9
 
+
+------------------------------------------------------------
+Fig. 5 - Missing children
+
+         -
+        /
+       /
+      /
+     *                 // <- a node with a single child is printed as if the missing child was there, but had no value:
+    / \                //    the distance to the missing child is the minimal one and only the connector to the existing child is drawn.
+   /   \
+  /     \
+ x       y
+
 */
@@ -0,0 +1,217 @@
+// Package proctree reads the hierarchy of processes from the Linux proc file system and shows it as a tree.
+//
+// The file system is read through fs.FS (e.g. os.DirFS("/proc")), so the hierarchy can also be read from a copy or a fake directory.
+// Only the "PID/stat" and "PID/status" files are used. Processes usually have more than two children,
+// so the children are folded with printer.Group().
+package proctree
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// RootLabel is the label of the node joining the processes without a parent, e.g. init and kthreadd, when no root PID is given.
+const RootLabel = "procs"
+
+// Options controls which processes are shown.
+type Options struct {
+	// RootPID selects the process shown at the root, with its descendants. Zero shows all processes.
+	RootPID int
+	// UID shows only the processes of the user with the given (real) user ID, and their ancestors, which connect them to the root.
+	// Empty means all users.
+	UID string
+}
+
+// Process is a process read from the proc file system.
+type Process struct {
+	PID      int
+	PPID     int
+	Comm     string // the name of the executable, as in the stat file
+	UID      string // the real user ID, empty if it couldn't be read
+	Children []*Process
+}
+
+// Label returns the label of the process: "PID:COMM".
+func (p *Process) Label() string {
+	return fmt.Sprintf("%d:%s", p.PID, p.Comm)
+}
+
+// Node converts the process and its descendants into a printer tree.
+func (p *Process) Node() *printer.Node {
+	children := make([]*printer.Node, 0, len(p.Children))
+	for _, child := range p.Children {
+		children = append(children, child.Node())
+	}
+	return printer.Group(p.Label(), children...)
+}
+
+// ReadAll reads all processes from the root directory of the file system and links them with their children.
+// It returns the processes whose parents are not present, ordered by PID. Processes that exit while being read are skipped.
+func ReadAll(fsys fs.FS) ([]*Process, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byPID := map[int]*Process{}
+	var all []*Process
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		p, err := readProcess(fsys, entry.Name())
+		if exited(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.PID != pid {
+			return nil, fmt.Errorf("%s/stat: unexpected PID %d", entry.Name(), p.PID)
+		}
+		byPID[pid] = p
+		all = append(all, p)
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].PID < all[j].PID })
+	var roots []*Process
+	for _, p := range all {
+		if parent, ok := byPID[p.PPID]; ok && p.PPID != p.PID {
+			parent.Children = append(parent.Children, p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+	return roots, nil
+}
+
+// Tree reads the processes from the file system and converts the hierarchy selected by the options into a printer tree.
+// When there's more than one process at the top, they're joined by a RootLabel node.
+func Tree(fsys fs.FS, opts Options) (*printer.Node, error) {
+	roots, err := ReadAll(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.RootPID != 0 {
+		root := find(roots, opts.RootPID)
+		if root == nil {
+			return nil, fmt.Errorf("no process with PID %d", opts.RootPID)
+		}
+		roots = []*Process{root}
+	}
+	if opts.UID != "" {
+		roots = filter(roots, opts.UID)
+		if len(roots) == 0 {
+			return nil, fmt.Errorf("no processes of the user with UID %s", opts.UID)
+		}
+	}
+
+	if len(roots) == 1 {
+		return roots[0].Node(), nil
+	}
+	nodes := make([]*printer.Node, 0, len(roots))
+	for _, root := range roots {
+		nodes = append(nodes, root.Node())
+	}
+	return printer.Group(RootLabel, nodes...), nil
+}
+
+func find(processes []*Process, pid int) *Process {
+	for _, p := range processes {
+		if p.PID == pid {
+			return p
+		}
+		if found := find(p.Children, pid); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// filter returns copies of the processes that belong to the user or have descendants that do, with only such children.
+func filter(processes []*Process, uid string) []*Process {
+	var result []*Process
+	for _, p := range processes {
+		children := filter(p.Children, uid)
+		if p.UID != uid && len(children) == 0 {
+			continue
+		}
+		filtered := *p
+		filtered.Children = children
+		result = append(result, &filtered)
+	}
+	return result
+}
+
+// readProcess reads the PID, the PPID and the name from the "PID/stat" file and the UID from the "PID/status" file.
+func readProcess(fsys fs.FS, dir string) (*Process, error) {
+	stat, err := fs.ReadFile(fsys, dir+"/stat")
+	if err != nil {
+		return nil, err
+	}
+	p, err := parseStat(stat)
+	if err != nil {
+		return nil, fmt.Errorf("%s/stat: %w", dir, err)
+	}
+
+	status, err := fs.ReadFile(fsys, dir+"/status")
+	if err != nil && !exited(err) {
+		return nil, err
+	}
+	p.UID = parseUID(status)
+	return p, nil
+}
+
+// exited tells whether the error of reading a file of a process means that the process has exited: its directory is gone,
+// or it's still there but the kernel no longer finds the process (ESRCH).
+func exited(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ESRCH)
+}
+
+// parseStat parses the beginning of a stat file: "PID (COMM) STATE PPID ...".
+// The name may contain spaces and parentheses, so it ends at the last closing parenthesis.
+func parseStat(stat []byte) (*Process, error) {
+	open := bytes.IndexByte(stat, '(')
+	closing := bytes.LastIndexByte(stat, ')')
+	if open < 0 || closing < open {
+		return nil, errors.New("missing process name")
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(stat[:open])))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PID: %w", err)
+	}
+	fields := strings.Fields(string(stat[closing+1:]))
+	if len(fields) < 2 {
+		return nil, errors.New("missing parent PID")
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent PID: %w", err)
+	}
+	return &Process{PID: pid, PPID: ppid, Comm: string(stat[open+1 : closing])}, nil
+}
+
+// parseUID returns the real UID from the "Uid:" line of a status file, or "" if there's no such line.
+func parseUID(status []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(status))
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(scanner.Text(), "Uid:"); ok {
+			if fields := strings.Fields(rest); len(fields) > 0 {
+				return fields[0]
+			}
+		}
+	}
+	return ""
+}
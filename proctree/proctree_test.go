@@ -0,0 +1,117 @@
+package proctree
+
+import (
+	"io/fs"
+	"syscall"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProc returns a proc file system with the processes given as "PID (COMM) STATE PPID" stat lines and UIDs.
+func fakeProc(processes map[string][2]string) fstest.MapFS {
+	fsys := fstest.MapFS{
+		"self":    &fstest.MapFile{Data: []byte("not a process directory")},
+		"cpuinfo": &fstest.MapFile{Data: []byte("processor : 0\n")},
+	}
+	for pid, p := range processes {
+		fsys[pid+"/stat"] = &fstest.MapFile{Data: []byte(p[0] + " 1 1 0 -1 4194560 0 0\n")}
+		fsys[pid+"/status"] = &fstest.MapFile{Data: []byte("Name:\tx\nUid:\t" + p[1] + "\t" + p[1] + "\t0\t0\nGid:\t0\t0\t0\t0\n")}
+	}
+	return fsys
+}
+
+var proc = fakeProc(map[string][2]string{
+	"1":   {"1 (init) S 0", "0"},
+	"2":   {"2 (kthreadd) S 0", "0"},
+	"3":   {"3 (kworker/0:0) I 2", "0"},
+	"10":  {"10 (sshd) S 1", "0"},
+	"11":  {"11 (sshd: alice) S 10", "1000"},
+	"12":  {"12 (bash) S 11", "1000"},
+	"13":  {"13 (my (odd) name) R 12", "1000"},
+	"20":  {"20 (cron) S 1", "0"},
+	"21":  {"21 (backup) S 20", "1001"},
+	"100": {"100 (zombie) Z 99", "0"},
+})
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		expected string
+	}{
+		{"all", Options{},
+			`(procs (... (1:init (10:sshd ("11:sshd: alice" (12:bash "13:my (odd) name"))) (20:cron 21:backup)) (2:kthreadd 3:kworker/0:0)) 100:zombie)`},
+		{"root", Options{RootPID: 10}, `(10:sshd ("11:sshd: alice" (12:bash "13:my (odd) name")))`},
+		{"user", Options{UID: "1001"}, `(1:init (20:cron 21:backup))`},
+		{"root and user", Options{RootPID: 12, UID: "1000"}, `(12:bash "13:my (odd) name")`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Tree(proc, tt.opts)
+		if assert.NoError(t, err, tt.name) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.name)
+		}
+	}
+}
+
+func TestTreeErrors(t *testing.T) {
+	_, err := Tree(proc, Options{RootPID: 42})
+	assert.EqualError(t, err, "no process with PID 42")
+
+	_, err = Tree(proc, Options{RootPID: 2, UID: "1000"})
+	assert.EqualError(t, err, "no processes of the user with UID 1000")
+
+	_, err = Tree(fstest.MapFS{"7/stat": &fstest.MapFile{Data: []byte("7 init S 0")}}, Options{})
+	assert.EqualError(t, err, "7/stat: missing process name")
+
+	_, err = Tree(fstest.MapFS{"7/stat": &fstest.MapFile{Data: []byte("8 (init) S 0")}}, Options{})
+	assert.EqualError(t, err, "7/stat: unexpected PID 8")
+}
+
+func TestReadAllSkipsExitedProcesses(t *testing.T) {
+	fsys := fakeProc(map[string][2]string{"1": {"1 (init) S 0", "0"}})
+	// A directory without the stat file, as left by a process that has just exited.
+	fsys["5/fd"] = &fstest.MapFile{Data: nil}
+
+	roots, err := ReadAll(fsys)
+	assert.NoError(t, err)
+	if assert.Len(t, roots, 1) {
+		assert.Equal(t, &Process{PID: 1, PPID: 0, Comm: "init", UID: "0"}, roots[0])
+	}
+}
+
+// exitingFS fails to read the files of the exiting processes with ESRCH, as /proc does for a process that exits while it's read.
+type exitingFS struct {
+	fstest.MapFS
+	exiting map[string]bool
+}
+
+func (f exitingFS) Open(name string) (fs.File, error) {
+	if f.exiting[name] {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: syscall.ESRCH}
+	}
+	return f.MapFS.Open(name)
+}
+
+func (f exitingFS) ReadFile(name string) ([]byte, error) {
+	if f.exiting[name] {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: syscall.ESRCH}
+	}
+	return f.MapFS.ReadFile(name)
+}
+
+func TestReadAllSkipsExitingProcesses(t *testing.T) {
+	fsys := exitingFS{
+		MapFS:   fakeProc(map[string][2]string{"1": {"1 (init) S 0", "0"}, "5": {"5 (sh) S 1", "1000"}, "6": {"6 (cat) S 1", "1000"}}),
+		exiting: map[string]bool{"5/stat": true, "6/status": true},
+	}
+
+	roots, err := ReadAll(fsys)
+	assert.NoError(t, err)
+	if assert.Len(t, roots, 1) {
+		assert.Equal(t, []*Process{{PID: 6, PPID: 1, Comm: "cat"}}, roots[0].Children)
+	}
+}
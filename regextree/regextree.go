@@ -0,0 +1,79 @@
+// Package regextree converts regular expression syntax trees (regexp/syntax) into printer.Node trees.
+//
+// Every node is labelled with the name of its operator (see syntax.Op), followed by the details that are not shown by the children:
+// the text of a literal, the ranges of a character class, the number and the name of a capture group, or the bounds of a repetition.
+// Concatenations and alternations with more than two parts are folded with printer.Group().
+package regextree
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strconv"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Parse parses the pattern with the given flags and converts the result. Use syntax.Perl for the syntax accepted by the regexp package.
+// The tree is converted as parsed; call FromRegexp with the result of syntax.Regexp.Simplify() to see the simplified tree.
+func Parse(pattern string, flags syntax.Flags) (*printer.Node, error) {
+	re, err := syntax.Parse(pattern, flags)
+	if err != nil {
+		return nil, err
+	}
+	return FromRegexp(re), nil
+}
+
+// FromRegexp converts the given regular expression syntax tree into a printer tree. Returns nil for a nil expression.
+func FromRegexp(re *syntax.Regexp) *printer.Node {
+	if re == nil {
+		return nil
+	}
+
+	children := make([]*printer.Node, 0, len(re.Sub))
+	for _, sub := range re.Sub {
+		children = append(children, FromRegexp(sub))
+	}
+	return printer.Group(label(re), children...)
+}
+
+// label returns the operator name with the details of the node, e.g. `Literal "ab"` or `Repeat {2,5}`.
+func label(re *syntax.Regexp) string {
+	op := re.Op.String()
+	switch re.Op {
+	case syntax.OpLiteral:
+		text := strconv.QuoteToASCII(string(re.Rune))
+		if re.Flags&syntax.FoldCase != 0 {
+			text = "(?i)" + text
+		}
+		return op + " " + text
+	case syntax.OpCharClass:
+		return op + " " + re.String()
+	case syntax.OpCapture:
+		if re.Name != "" {
+			return fmt.Sprintf("%s #%d <%s>", op, re.Cap, re.Name)
+		}
+		return fmt.Sprintf("%s #%d", op, re.Cap)
+	case syntax.OpRepeat:
+		return op + " " + bounds(re.Min, re.Max) + greediness(re)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		return op + greediness(re)
+	}
+	return op
+}
+
+func bounds(min, max int) string {
+	switch max {
+	case -1:
+		return fmt.Sprintf("{%d,}", min)
+	case min:
+		return fmt.Sprintf("{%d}", min)
+	}
+	return fmt.Sprintf("{%d,%d}", min, max)
+}
+
+func greediness(re *syntax.Regexp) string {
+	if re.Flags&syntax.NonGreedy != 0 {
+		return " non-greedy"
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package regextree
+
+import (
+	"regexp/syntax"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{`abc`, `"Literal \"abc\""`},
+		{`a(b|c)*`, `(Concat "Literal \"a\"" (Star ("Capture #1" "CharClass [bc]")))`},
+		{`(?P<year>\d{4})`, `("Capture #1 <year>" ("Repeat {4}" "CharClass [0-9]"))`},
+		{`x{2,}?y+?`, `(Concat ("Repeat {2,} non-greedy" "Literal \"x\"") ("Plus non-greedy" "Literal \"y\""))`},
+		{`(?i)k?`, `(Quest "Literal (?i)\"K\"")`},
+		{`^foo|bar|.$`, `(Alternate (... (Concat BeginText "Literal \"foo\"") "Literal \"bar\"") (Concat AnyCharNotNL EndText))`},
+		{`\x{e9}`, `"Literal \"\\u00e9\""`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(tt.pattern, syntax.Perl)
+		if assert.NoError(t, err, tt.pattern) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.pattern)
+		}
+	}
+}
+
+func TestFromRegexpSimplified(t *testing.T) {
+	re, err := syntax.Parse(`a{2,3}`, syntax.Perl)
+	assert.NoError(t, err)
+	assert.Equal(t, `(Concat (... "Literal \"a\"" "Literal \"a\"") (Quest "Literal \"a\""))`, sexpr.Format(FromRegexp(re.Simplify())))
+	assert.Nil(t, FromRegexp(nil))
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse(`a(b`, syntax.Perl)
+	assert.EqualError(t, err, "error parsing regexp: missing closing ): `a(b`")
+}
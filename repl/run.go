@@ -0,0 +1,91 @@
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Prompt is printed before every command.
+const Prompt = "> "
+
+// EmptyTree is printed instead of the drawing of an empty tree.
+const EmptyTree = "(empty tree)"
+
+// Render turns the tree into the text printed after every change. It's never called with a nil tree.
+type Render func(root *printer.Node) (string, error)
+
+// Run reads commands from r and executes them, printing the tree to w after every change, until quit or the end of input.
+// Errors of the commands are printed and the session goes on, only the errors of reading and writing are returned.
+func Run(s *Session, r io.Reader, w io.Writer, render Render) error {
+	scanner := bufio.NewScanner(r)
+	for {
+		if _, err := io.WriteString(w, Prompt); err != nil {
+			return err
+		}
+		if !scanner.Scan() {
+			_, err := io.WriteString(w, "\n")
+			if scanErr := scanner.Err(); scanErr != nil {
+				return scanErr
+			}
+			return err
+		}
+
+		changed, err := s.Exec(scanner.Text())
+		switch {
+		case errors.Is(err, ErrQuit):
+			return nil
+		case err != nil:
+			_, err = fmt.Fprintf(w, "error: %v\n", err)
+		case strings.TrimSpace(scanner.Text()) == "help":
+			_, err = fmt.Fprintln(w, Help)
+		case changed:
+			err = show(s.Root(), w, render)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ScriptCommands are the commands written to scripts by Script(), the only ones Replay executes.
+var ScriptCommands = []string{"set", "add", "replace", "delete"}
+
+// Replay executes the commands of a script and returns the tree they build. The first failing command stops the replay;
+// its error is prefixed with the name of the script and the line number.
+// Only the ScriptCommands, empty lines and comments are accepted, so a script neither reads nor writes files,
+// and it builds the same tree whenever it's replayed.
+func (s *Session) Replay(name string, r io.Reader) (*printer.Node, error) {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		command, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if command != "" && !strings.HasPrefix(command, "#") && !slices.Contains(ScriptCommands, command) {
+			return nil, fmt.Errorf("%s:%d: %q is not allowed in scripts, only %s", name, line, command, strings.Join(ScriptCommands, ", "))
+		}
+		_, err := s.Exec(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, line, err)
+		}
+	}
+	return s.Root(), scanner.Err()
+}
+
+// show prints the tree, or EmptyTree.
+func show(root *printer.Node, w io.Writer, render Render) error {
+	if root == nil {
+		_, err := fmt.Fprintln(w, EmptyTree)
+		return err
+	}
+	text, err := render(root)
+	if err != nil {
+		_, err = fmt.Fprintf(w, "error: %v\n", err)
+		return err
+	}
+	_, err = io.WriteString(w, text)
+	return err
+}
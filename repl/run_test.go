@@ -0,0 +1,58 @@
+package repl
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func renderSexpr(root *printer.Node) (string, error) {
+	return sexpr.Format(root) + "\n", nil
+}
+
+func TestRun(t *testing.T) {
+	input := "set root +\nadd L 1\nadd L 2\n\ndelete root\nundo\nquit\nadd R 3\n"
+	var output bytes.Buffer
+	s := &Session{}
+	assert.NoError(t, Run(s, strings.NewReader(input), &output, renderSexpr))
+	assert.Equal(t, "> +\n> (+ 1)\n> error: the node already exists (1), use set or replace\n> > (empty tree)\n> (+ 1)\n> ", output.String())
+	assert.Equal(t, "(+ 1)", sexpr.Format(s.Root()))
+
+	output.Reset()
+	assert.NoError(t, Run(&Session{}, strings.NewReader("help"), &output, renderSexpr))
+	assert.Equal(t, "> "+Help+"\n> \n", output.String())
+}
+
+func TestReplay(t *testing.T) {
+	s := &Session{}
+	root, err := s.Replay("build.tree", strings.NewReader("# a tree\nset root a\nadd L b\n\nadd R c\nreplace R d\ndelete L\n"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "(a () d)", sexpr.Format(root))
+	}
+	assert.Equal(t, "set root a\nadd L b\nadd R c\nreplace R d\ndelete L\n", s.Script())
+
+	_, err = (&Session{}).Replay("bad.tree", strings.NewReader("set root a\n\nadd LL b\n"))
+	assert.EqualError(t, err, "bad.tree:3: the parent of the node doesn't exist")
+}
+
+// Scripts can't read or write files, or contain anything else that Script doesn't write.
+func TestReplayRejectsOtherCommands(t *testing.T) {
+	saved := filepath.Join(t.TempDir(), "saved.tree")
+	for _, command := range []string{"save " + saved, "load tree.json", "help", "undo", "quit"} {
+		loaded := false
+		s := &Session{Load: func(string) (*printer.Node, error) {
+			loaded = true
+			return &printer.Node{Value: "x"}, nil
+		}}
+		_, err := s.Replay("bad.tree", strings.NewReader("set root a\n"+command+"\n"))
+		name, _, _ := strings.Cut(command, " ")
+		assert.EqualError(t, err, `bad.tree:2: "`+name+`" is not allowed in scripts, only set, add, replace, delete`)
+		assert.False(t, loaded, command)
+	}
+	assert.NoFileExists(t, saved)
+}
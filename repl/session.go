@@ -0,0 +1,277 @@
+// Package repl builds and edits printer.Node trees with line commands, e.g. "set root +", "add L 1" or "undo".
+//
+// Nodes are addressed by paths: "root" is the root and a string of L and R letters is the node reached from the root
+// by going to the left and right children, e.g. "LR" is the right child of the left child of the root.
+// A Session keeps the current tree and the commands that built it, which can be saved as a script.
+// Replaying the script with Replay builds the same tree again: a loaded tree is saved as the commands building it, not as
+// the load command, and Replay accepts only the commands that build trees, so scripts neither depend on nor write other files. Values with surrounding spaces or unprintable characters
+// are written as Go string literals, e.g. "set root \" x \"".
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// RootPath is the path of the root node.
+const RootPath = "root"
+
+// Help describes the commands.
+const Help = `commands:
+  set PATH VALUE       set the value of the node at PATH, adding the node if it's missing
+  add PATH VALUE       add a leaf at PATH, e.g. "add L 1" or "add LR foo"
+  replace PATH VALUE   replace the value of the node at PATH
+  delete PATH          delete the node at PATH with its subtree
+  undo                 undo the last change
+  load FILE            replace the tree with the one read from FILE
+  save FILE            save the commands building the tree as a script (a loaded tree is saved as commands too)
+  help                 print this help
+  quit                 leave the session
+PATH is "root" or a sequence of L and R (left and right child), starting at the root.
+VALUE may be a quoted Go string, e.g. " x " for a value with spaces around it.`
+
+// ErrQuit is returned by Exec for the quit command.
+var ErrQuit = errors.New("quit")
+
+// Session is the state of a REPL: the current tree and the changes made to it.
+type Session struct {
+	// Load reads the tree of the load command from a file.
+	Load func(path string) (*printer.Node, error)
+
+	root    *printer.Node
+	history []change
+}
+
+// change is a command that changed the tree, with the tree from before the command, restored by undo.
+// The commands are the ones written to scripts, usually just the executed command.
+type change struct {
+	commands []string
+	before   *printer.Node
+}
+
+// Root returns the current tree, nil if it's empty. The tree must not be modified.
+func (s *Session) Root() *printer.Node {
+	return s.root
+}
+
+// Script returns the commands building the current tree, one per line. Undone commands are left out.
+func (s *Session) Script() string {
+	var sb strings.Builder
+	for _, c := range s.history {
+		for _, command := range c.commands {
+			sb.WriteString(command + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// Exec executes a single command line. Empty lines and comments starting with # are ignored.
+// It returns whether the tree has changed, and ErrQuit for the quit command.
+func (s *Session) Exec(line string) (bool, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return false, nil
+	}
+	name, args, _ := strings.Cut(line, " ")
+	args = strings.TrimSpace(args)
+
+	switch name {
+	case "set", "add", "replace":
+		path, value, _ := strings.Cut(args, " ")
+		value = strings.TrimSpace(value)
+		if path == "" || value == "" {
+			return false, fmt.Errorf("usage: %s PATH VALUE", name)
+		}
+		steps, err := parsePath(path)
+		if err != nil {
+			return false, err
+		}
+		if value, err = parseValue(value); err != nil {
+			return false, err
+		}
+		root, err := setValue(s.root, steps, value, name)
+		if err != nil {
+			return false, err
+		}
+		s.change(root, name+" "+formatPath(steps)+" "+formatValue(value))
+	case "delete":
+		steps, err := parsePath(args)
+		if err != nil {
+			return false, err
+		}
+		root, err := deleteNode(s.root, steps)
+		if err != nil {
+			return false, err
+		}
+		s.change(root, "delete "+formatPath(steps))
+	case "undo":
+		if args != "" {
+			return false, errors.New("usage: undo")
+		}
+		if len(s.history) == 0 {
+			return false, errors.New("nothing to undo")
+		}
+		last := s.history[len(s.history)-1]
+		s.root, s.history = last.before, s.history[:len(s.history)-1]
+	case "load":
+		if args == "" {
+			return false, errors.New("usage: load FILE")
+		}
+		if s.Load == nil {
+			return false, errors.New("loading files is not supported")
+		}
+		root, err := s.Load(args)
+		if err != nil {
+			return false, err
+		}
+		if root == nil {
+			return false, fmt.Errorf("%s has no tree", args)
+		}
+		var commands []string
+		if s.root != nil {
+			commands = append(commands, "delete "+RootPath)
+		}
+		s.change(root, append(commands, buildCommands(root, nil)...)...)
+	case "save":
+		if args == "" {
+			return false, errors.New("usage: save FILE")
+		}
+		return false, os.WriteFile(args, []byte(s.Script()), 0o644)
+	case "help":
+		return false, nil
+	case "quit", "exit":
+		return false, ErrQuit
+	default:
+		return false, fmt.Errorf("unknown command %q, type help for the list of commands", name)
+	}
+	return true, nil
+}
+
+// change replaces the tree, remembering the commands and the previous tree.
+func (s *Session) change(root *printer.Node, commands ...string) {
+	s.history = append(s.history, change{commands: commands, before: s.root})
+	s.root = root
+}
+
+// buildCommands returns the commands adding the subtree at the path, in preorder: "set root" for the root, "add" for the others.
+func buildCommands(n *printer.Node, steps []bool) []string {
+	if n == nil {
+		return nil
+	}
+	command := "add "
+	if len(steps) == 0 {
+		command = "set "
+	}
+	commands := []string{command + formatPath(steps) + " " + formatValue(n.Value)}
+	commands = append(commands, buildCommands(n.LeftChild, append(steps[:len(steps):len(steps)], false))...)
+	return append(commands, buildCommands(n.RightChild, append(steps[:len(steps):len(steps)], true))...)
+}
+
+// parseValue returns the value of a command, unquoting it if it's a Go string literal.
+func parseValue(value string) (string, error) {
+	if !strings.HasPrefix(value, `"`) {
+		return value, nil
+	}
+	unquoted, err := strconv.Unquote(value)
+	if err != nil || unquoted == "" {
+		return "", fmt.Errorf("invalid quoted value %s", value)
+	}
+	return unquoted, nil
+}
+
+// formatValue returns the value as written in commands: as is, unless it has to be quoted to be read back the same.
+func formatValue(value string) string {
+	if value != strings.TrimSpace(value) || strings.HasPrefix(value, `"`) || strings.IndexFunc(value, func(r rune) bool { return !strconv.IsPrint(r) }) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// parsePath returns the steps of a path: false for the left child and true for the right one. The root has no steps.
+func parsePath(path string) ([]bool, error) {
+	if path == RootPath {
+		return nil, nil
+	}
+	if path == "" {
+		return nil, errors.New(`missing PATH, use "root" or a sequence of L and R`)
+	}
+	steps := make([]bool, len(path))
+	for i, step := range strings.ToUpper(path) {
+		switch step {
+		case 'L':
+		case 'R':
+			steps[i] = true
+		default:
+			return nil, fmt.Errorf(`invalid path %q, use "root" or a sequence of L and R`, path)
+		}
+	}
+	return steps, nil
+}
+
+// formatPath returns the path of the steps, with uppercase letters.
+func formatPath(steps []bool) string {
+	if len(steps) == 0 {
+		return RootPath
+	}
+	var sb strings.Builder
+	for _, right := range steps {
+		if right {
+			sb.WriteByte('R')
+		} else {
+			sb.WriteByte('L')
+		}
+	}
+	return sb.String()
+}
+
+// setValue returns a copy of the tree with the value of the node at the path set, as the set, add or replace command.
+// Only the nodes on the path are copied, the rest of the tree is shared, so that the previous tree stays intact.
+func setValue(n *printer.Node, steps []bool, value, command string) (*printer.Node, error) {
+	if len(steps) == 0 {
+		switch {
+		case n == nil && command == "replace":
+			return nil, errors.New("no node to replace")
+		case n != nil && command == "add":
+			return nil, fmt.Errorf("the node already exists (%s), use set or replace", n.Value)
+		case n == nil:
+			return &printer.Node{Value: value}, nil
+		}
+		return &printer.Node{Value: value, LeftChild: n.LeftChild, RightChild: n.RightChild}, nil
+	}
+	if n == nil {
+		return nil, errors.New("the parent of the node doesn't exist")
+	}
+
+	copied := *n
+	child := &copied.LeftChild
+	if steps[0] {
+		child = &copied.RightChild
+	}
+	var err error
+	*child, err = setValue(*child, steps[1:], value, command)
+	return &copied, err
+}
+
+// deleteNode returns a copy of the tree without the node at the path and its subtree.
+func deleteNode(n *printer.Node, steps []bool) (*printer.Node, error) {
+	if n == nil {
+		return nil, errors.New("no node to delete")
+	}
+	if len(steps) == 0 {
+		return nil, nil
+	}
+
+	copied := *n
+	child := &copied.LeftChild
+	if steps[0] {
+		child = &copied.RightChild
+	}
+	var err error
+	*child, err = deleteNode(*child, steps[1:])
+	return &copied, err
+}
@@ -0,0 +1,170 @@
+package repl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func tree(s *Session) string {
+	if s.Root() == nil {
+		return ""
+	}
+	return sexpr.Format(s.Root())
+}
+
+func TestExec(t *testing.T) {
+	tests := []struct {
+		command  string
+		changed  bool
+		expected string
+	}{
+		{"set root +", true, "+"},
+		{"add L 1", true, "(+ 1)"},
+		{"add r 2", true, "(+ 1 2)"},
+		{"add LR foo bar", true, "(+ (1 () \"foo bar\") 2)"},
+		{"replace LR foo", true, "(+ (1 () foo) 2)"},
+		{"set RL x", true, "(+ (1 () foo) (2 x))"},
+		{"set root *", true, "(* (1 () foo) (2 x))"},
+		{"  # a comment", false, "(* (1 () foo) (2 x))"},
+		{"", false, "(* (1 () foo) (2 x))"},
+		{"delete R", true, "(* (1 () foo))"},
+		{"undo", true, "(* (1 () foo) (2 x))"},
+		{"undo", true, "(+ (1 () foo) (2 x))"},
+		{"help", false, "(+ (1 () foo) (2 x))"},
+		{"delete root", true, ""},
+		{"undo", true, "(+ (1 () foo) (2 x))"},
+	}
+
+	s := &Session{}
+	for _, tt := range tests {
+		changed, err := s.Exec(tt.command)
+		if assert.NoError(t, err, tt.command) {
+			assert.Equal(t, tt.changed, changed, tt.command)
+			assert.Equal(t, tt.expected, tree(s), tt.command)
+		}
+	}
+	assert.Equal(t, "set root +\nadd L 1\nadd R 2\nadd LR foo bar\nreplace LR foo\nset RL x\n", s.Script())
+}
+
+func TestExecErrors(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected string
+	}{
+		{"add L 1", "the parent of the node doesn't exist"},
+		{"replace root x", "no node to replace"},
+		{"delete root", "no node to delete"},
+		{"undo", "nothing to undo"},
+		{"set root", "usage: set PATH VALUE"},
+		{"add", "usage: add PATH VALUE"},
+		{"add LX 1", `invalid path "LX", use "root" or a sequence of L and R`},
+		{"delete", `missing PATH, use "root" or a sequence of L and R`},
+		{"load", "usage: load FILE"},
+		{"load tree.json", "loading files is not supported"},
+		{"save", "usage: save FILE"},
+		{"undo 2", "usage: undo"},
+		{"draw", `unknown command "draw", type help for the list of commands`},
+	}
+
+	for _, tt := range tests {
+		changed, err := (&Session{}).Exec(tt.command)
+		assert.EqualError(t, err, tt.expected, tt.command)
+		assert.False(t, changed, tt.command)
+	}
+
+	s := &Session{}
+	for _, command := range []string{"set root a", "add L b"} {
+		_, err := s.Exec(command)
+		assert.NoError(t, err)
+	}
+	_, err := s.Exec("add L c")
+	assert.EqualError(t, err, "the node already exists (b), use set or replace")
+	_, err = s.Exec("delete R")
+	assert.EqualError(t, err, "no node to delete")
+	assert.Equal(t, "(a b)", tree(s))
+
+	_, err = s.Exec("quit")
+	assert.ErrorIs(t, err, ErrQuit)
+}
+
+func TestExecKeepsPreviousTrees(t *testing.T) {
+	s := &Session{}
+	for _, command := range []string{"set root a", "add L b", "add R c"} {
+		_, err := s.Exec(command)
+		assert.NoError(t, err)
+	}
+	before := s.Root()
+
+	for _, command := range []string{"replace L x", "delete R", "set LL y"} {
+		_, err := s.Exec(command)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, "(a (x y))", tree(s))
+	assert.Equal(t, "(a b c)", sexpr.Format(before))
+}
+
+func TestLoadAndSave(t *testing.T) {
+	loaded := &printer.Node{Value: "f", LeftChild: &printer.Node{Value: "x"}}
+	s := &Session{Load: func(path string) (*printer.Node, error) {
+		if path != "tree.json" {
+			return nil, errors.New("no such file")
+		}
+		return loaded, nil
+	}}
+
+	for _, command := range []string{"set root a", "load tree.json", "add R y"} {
+		_, err := s.Exec(command)
+		assert.NoError(t, err, command)
+	}
+	assert.Equal(t, "(f x y)", tree(s))
+	assert.Nil(t, loaded.RightChild)
+
+	_, err := s.Exec("load other.json")
+	assert.EqualError(t, err, "no such file")
+
+	path := filepath.Join(t.TempDir(), "session.tree")
+	changed, err := s.Exec("save " + path)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	script, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "set root a\ndelete root\nset root f\nadd L x\nadd R y\n", string(script))
+
+	_, err = s.Exec("undo")
+	assert.NoError(t, err)
+	_, err = s.Exec("undo")
+	assert.NoError(t, err)
+	assert.Equal(t, "set root a\n", s.Script(), "undo removes all the commands of a load")
+}
+
+func TestQuotedValues(t *testing.T) {
+	s := &Session{}
+	for _, command := range []string{`set root " a "`, `add L "\"quoted\""`, "add R tab\there", `add LL "x\ny"`, `add LR a "b"`} {
+		_, err := s.Exec(command)
+		assert.NoError(t, err, command)
+	}
+	assert.Equal(t, " a ", s.Root().Value)
+	assert.Equal(t, `"quoted"`, s.Root().LeftChild.Value)
+	assert.Equal(t, "x\ny", s.Root().LeftChild.LeftChild.Value)
+	assert.Equal(t, `a "b"`, s.Root().LeftChild.RightChild.Value)
+	assert.Equal(t, `set root " a "`+"\n"+`add L "\"quoted\""`+"\n"+`add R "tab\there"`+"\n"+`add LL "x\ny"`+"\n"+`add LR a "b"`+"\n", s.Script())
+
+	replayed := &Session{}
+	for _, command := range strings.Split(strings.TrimSuffix(s.Script(), "\n"), "\n") {
+		_, err := replayed.Exec(command)
+		assert.NoError(t, err, command)
+	}
+	assert.Equal(t, s.Root(), replayed.Root())
+
+	for _, command := range []string{`set root "a`, `set root ""`} {
+		_, err := s.Exec(command)
+		assert.EqualError(t, err, "invalid quoted value "+strings.TrimPrefix(command, "set root "))
+	}
+}
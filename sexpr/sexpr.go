@@ -0,0 +1,237 @@
+// Package sexpr converts between printer.Node trees and s-expressions such as "(+ 1 (* 2 3))".
+//
+// A leaf is an atom: "1", or a list with just the value: "(1)".
+// A node with children is a list of the value and one or two children: "(- x)", "(+ 1 2)".
+// An empty list "()" stands for a missing child, so a node with just the right child is written as "(neg () x)".
+//
+// Atoms are runs of characters other than whitespace, parentheses, double quotes and semicolons.
+// Other values are written as double-quoted strings, using the Go syntax for escapes: "\"a b\"\n".
+// A semicolon starts a comment that lasts until the end of the line.
+package sexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/internal/syntax"
+)
+
+// Position is a location in the parsed input. Both the line and the column start at 1, the column counts runes, not bytes.
+type Position = syntax.Position
+
+// SyntaxError describes a problem with the parsed s-expression and its position.
+type SyntaxError = syntax.Error
+
+// Parse parses a single s-expression and returns its tree. Errors are of type *SyntaxError.
+func Parse(input string) (*printer.Node, error) {
+	p := &parser{Scanner: syntax.NewScanner(input)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	start := p.tok.pos
+	root, err := p.parseNode()
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, &SyntaxError{Pos: start, Msg: "empty tree"}
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected("end of input")
+	}
+	return root, nil
+}
+
+// Format returns the s-expression of the tree. Parse(Format(root)) results in a tree equal to root.
+func Format(root *printer.Node) string {
+	var sb strings.Builder
+	format(&sb, root)
+	return sb.String()
+}
+
+func format(sb *strings.Builder, n *printer.Node) {
+	if n == nil {
+		sb.WriteString("()")
+		return
+	}
+	if n.IsLeaf() {
+		sb.WriteString(quote(n.Value))
+		return
+	}
+
+	sb.WriteString("(")
+	sb.WriteString(quote(n.Value))
+	sb.WriteString(" ")
+	format(sb, n.LeftChild)
+	if n.RightChild != nil {
+		sb.WriteString(" ")
+		format(sb, n.RightChild)
+	}
+	sb.WriteString(")")
+}
+
+// quote returns the value as an atom, or as a quoted string if it can't be written as an atom.
+func quote(value string) string {
+	if value == "" || strings.IndexFunc(value, func(r rune) bool { return !isAtomRune(r) || !unicode.IsPrint(r) }) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func isAtomRune(r rune) bool {
+	return !unicode.IsSpace(r) && r != '(' && r != ')' && r != '"' && r != ';'
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokLParen
+	tokRParen
+	tokAtom // an atom or a quoted string
+)
+
+type token struct {
+	kind  tokenKind
+	value string // the unquoted value of an atom
+	text  string // the source text of the token
+	pos   Position
+}
+
+func (t token) describe() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// parser is a recursive descent parser that reads tokens straight from the input, keeping track of their positions.
+type parser struct {
+	syntax.Scanner
+	tok token
+}
+
+func (p *parser) unexpected(expected string) error {
+	return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("unexpected %s, expected %s", p.tok.describe(), expected)}
+}
+
+// parseNode parses an atom or a list. The empty list results in a nil node.
+func (p *parser) parseNode() (*printer.Node, error) {
+	switch p.tok.kind {
+	case tokAtom:
+		value := p.tok.value
+		if value == "" {
+			return nil, &SyntaxError{Pos: p.tok.pos, Msg: "empty value"}
+		}
+		return &printer.Node{Value: value}, p.advance()
+	case tokLParen:
+		return p.parseList()
+	}
+	return nil, p.unexpected(`a value or "("`)
+}
+
+// parseList parses a list, the current token is the opening parenthesis.
+func (p *parser) parseList() (*printer.Node, error) {
+	start := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokRParen {
+		return nil, p.advance()
+	}
+	if p.tok.kind != tokAtom {
+		return nil, p.unexpected("a value")
+	}
+
+	node, err := p.parseNode()
+	if err != nil {
+		return nil, err
+	}
+
+	var children []*printer.Node
+	for p.tok.kind != tokRParen {
+		if p.tok.kind == tokEOF {
+			return nil, &SyntaxError{Pos: start, Msg: `unclosed "("`}
+		}
+		if len(children) == 2 {
+			return nil, &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("node %q has more than two children", node.Value)}
+		}
+		child, err := p.parseNode()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	switch len(children) {
+	case 2:
+		node.RightChild = children[1]
+		fallthrough
+	case 1:
+		node.LeftChild = children[0]
+	}
+	if node.LeftChild == nil && node.RightChild == nil && len(children) > 0 {
+		return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("node %q has only missing children", node.Value)}
+	}
+	return node, p.advance()
+}
+
+// skipSpace skips whitespace and comments.
+func (p *parser) skipSpace() {
+	for !p.AtEnd() {
+		r := p.PeekRune()
+		switch {
+		case r == ';':
+			for !p.AtEnd() && p.PeekRune() != '\n' {
+				p.NextRune()
+			}
+		case unicode.IsSpace(r):
+			p.NextRune()
+		default:
+			return
+		}
+	}
+}
+
+// advance reads the next token.
+func (p *parser) advance() error {
+	p.skipSpace()
+
+	start := p.Offset
+	pos := p.Pos
+	if p.AtEnd() {
+		p.tok = token{kind: tokEOF, pos: pos}
+		return nil
+	}
+
+	r := p.NextRune()
+	switch {
+	case r == '(':
+		p.tok = token{kind: tokLParen, text: "(", pos: pos}
+	case r == ')':
+		p.tok = token{kind: tokRParen, text: ")", pos: pos}
+	case r == '"':
+		return p.readString(start, pos)
+	default:
+		for !p.AtEnd() && isAtomRune(p.PeekRune()) {
+			p.NextRune()
+		}
+		text := p.Input[start:p.Offset]
+		p.tok = token{kind: tokAtom, value: text, text: text, pos: pos}
+	}
+	return nil
+}
+
+// readString reads a quoted string, the opening quote is already consumed.
+func (p *parser) readString(start int, pos Position) error {
+	text, value, err := p.ReadQuoted(start, pos)
+	if err != nil {
+		return err
+	}
+	p.tok = token{kind: tokAtom, value: value, text: text, pos: pos}
+	return nil
+}
@@ -0,0 +1,96 @@
+package sexpr
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *printer.Node
+	}{
+		{"1", &printer.Node{Value: "1"}},
+		{"(1)", &printer.Node{Value: "1"}},
+		{"(- x)", &printer.Node{Value: "-", LeftChild: &printer.Node{Value: "x"}}},
+		{"(neg () x)", &printer.Node{Value: "neg", RightChild: &printer.Node{Value: "x"}}},
+		{
+			"(+ 1 (* 2 3))",
+			&printer.Node{
+				Value:     "+",
+				LeftChild: &printer.Node{Value: "1"},
+				RightChild: &printer.Node{
+					Value:      "*",
+					LeftChild:  &printer.Node{Value: "2"},
+					RightChild: &printer.Node{Value: "3"},
+				},
+			},
+		},
+		{
+			"; a comment\n(\"a b\" \"\\\"q\\\"\" (f(x)))  ; another one",
+			&printer.Node{
+				Value:      "a b",
+				LeftChild:  &printer.Node{Value: `"q"`},
+				RightChild: &printer.Node{Value: "f", LeftChild: &printer.Node{Value: "x"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(tt.input)
+		if assert.NoError(t, err, tt.input) {
+			assert.Equal(t, tt.expected, actual, tt.input)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", `1:1: unexpected end of input, expected a value or "("`},
+		{"  ()", `1:3: empty tree`},
+		{"(+ 1 2", `1:1: unclosed "("`},
+		{"(+ 1 2 3)", `1:8: node "+" has more than two children`},
+		{"(+ 1\n  2) 3", `2:6: unexpected "3", expected end of input`},
+		{"((+) 1)", `1:2: unexpected "(", expected a value`},
+		{"(+ () ())", `1:1: node "+" has only missing children`},
+		{`(+ "" 1)`, `1:4: empty value`},
+		{`(+ "abc`, `1:4: unterminated quoted string`},
+		{`(+ "\q")`, `1:4: invalid quoted string "\q"`},
+		{")", `1:1: unexpected ")", expected a value or "("`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var syntaxErr *SyntaxError
+		if assert.ErrorAs(t, err, &syntaxErr, tt.input) {
+			assert.Equal(t, tt.expected, err.Error(), tt.input)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		tree     *printer.Node
+		expected string
+	}{
+		{&printer.Node{Value: "1"}, "1"},
+		{&printer.Node{Value: "-", LeftChild: &printer.Node{Value: "x"}}, "(- x)"},
+		{&printer.Node{Value: "neg", RightChild: &printer.Node{Value: "x"}}, "(neg () x)"},
+		{&printer.Node{Value: "a b", LeftChild: &printer.Node{Value: "(x)"}, RightChild: &printer.Node{Value: "y;\n"}}, `("a b" "(x)" "y;\n")`},
+	}
+
+	for _, tt := range tests {
+		actual := Format(tt.tree)
+		assert.Equal(t, tt.expected, actual)
+
+		parsed, err := Parse(actual)
+		if assert.NoError(t, err, actual) {
+			assert.Equal(t, tt.tree, parsed, actual)
+		}
+	}
+}
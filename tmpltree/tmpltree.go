@@ -0,0 +1,146 @@
+// Package tmpltree converts template parse trees (text/template/parse) into printer.Node trees.
+// The trees of html/template are the same, because both packages use the same parser.
+//
+// Control structures are labelled with their keyword ("if", "range", "with", "template") and have the pipeline on the left
+// and the body on the right. When there's an {{else}}, the right child is a "then/else" node with both bodies.
+// A pipeline is labelled "pipe" (followed by the declared variables, e.g. "pipe $x :=") and has the commands as its children,
+// and a command has its arguments as its children. Arguments and text are shown as they appear in the template.
+// Nodes with more than two children are folded with printer.Group().
+package tmpltree
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// MaxText is the maximum number of characters of text and comments shown in a node. Longer text is cut and ends with "...".
+const MaxText = 24
+
+// Parse parses the template text and converts the result. Functions aren't checked, so the text may call any function.
+//
+// Templates defined in the text with {{define}} or {{block}} are returned together with the main one:
+// the result is then a "templates" node with a `define "NAME"` child for every template, starting with the main one.
+func Parse(name, text string) (*printer.Node, error) {
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck | parse.ParseComments
+	treeSet := map[string]*parse.Tree{}
+	if _, err := tree.Parse(text, "", "", treeSet); err != nil {
+		return nil, err
+	}
+
+	if main, ok := treeSet[name]; ok && len(treeSet) == 1 {
+		return FromNode(main.Root), nil
+	}
+
+	names := make([]string, 0, len(treeSet))
+	for n := range treeSet {
+		if n != name {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := treeSet[name]; ok {
+		names = append([]string{name}, names...)
+	}
+
+	var defines []*printer.Node
+	for _, n := range names {
+		defines = append(defines, printer.Group("define "+strconv.Quote(n), FromNode(treeSet[n].Root)))
+	}
+	return printer.Group("templates", defines...), nil
+}
+
+// FromNode converts the given template parse tree into a printer tree. Returns nil for a nil node.
+func FromNode(n parse.Node) *printer.Node {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		return printer.Group("list", nodes(n.Nodes)...)
+	case *parse.TextNode:
+		return &printer.Node{Value: quote(string(n.Text))}
+	case *parse.CommentNode:
+		return &printer.Node{Value: "comment " + quote(n.Text)}
+	case *parse.ActionNode:
+		return printer.Group("action", FromNode(n.Pipe))
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		return printer.Group(pipeLabel(n), commands(n.Cmds)...)
+	case *parse.CommandNode:
+		return printer.Group("command", nodes(n.Args)...)
+	case *parse.IfNode:
+		return branch("if", &n.BranchNode)
+	case *parse.RangeNode:
+		return branch("range", &n.BranchNode)
+	case *parse.WithNode:
+		return branch("with", &n.BranchNode)
+	case *parse.TemplateNode:
+		return printer.Group("template "+strconv.Quote(n.Name), FromNode(n.Pipe))
+	case *parse.StringNode:
+		return &printer.Node{Value: quote(n.Text)}
+	case *parse.BreakNode:
+		return &printer.Node{Value: "break"}
+	case *parse.ContinueNode:
+		return &printer.Node{Value: "continue"}
+	case nil:
+		return nil
+	}
+	// Identifiers, fields, variables, chains, constants, dot and nil.
+	return &printer.Node{Value: n.String()}
+}
+
+// branch converts the common part of if, range and with.
+func branch(keyword string, n *parse.BranchNode) *printer.Node {
+	body := FromNode(n.List)
+	if n.ElseList != nil {
+		body = printer.Group("then/else", body, FromNode(n.ElseList))
+	}
+	return printer.Group(keyword, FromNode(n.Pipe), body)
+}
+
+func pipeLabel(n *parse.PipeNode) string {
+	if len(n.Decl) == 0 {
+		return "pipe"
+	}
+	vars := make([]string, 0, len(n.Decl))
+	for _, v := range n.Decl {
+		vars = append(vars, v.String())
+	}
+	assign := ":="
+	if n.IsAssign {
+		assign = "="
+	}
+	return "pipe " + strings.Join(vars, ", ") + " " + assign
+}
+
+func nodes(list []parse.Node) []*printer.Node {
+	result := make([]*printer.Node, 0, len(list))
+	for _, n := range list {
+		result = append(result, FromNode(n))
+	}
+	return result
+}
+
+func commands(list []*parse.CommandNode) []*printer.Node {
+	result := make([]*printer.Node, 0, len(list))
+	for _, n := range list {
+		result = append(result, FromNode(n))
+	}
+	return result
+}
+
+// quote returns the text in Go syntax, cut after MaxText characters. Newlines and other special characters are escaped,
+// so they don't break the rows of the rendering.
+func quote(text string) string {
+	if runes := []rune(text); len(runes) > MaxText {
+		return strconv.QuoteToASCII(string(runes[:MaxText])) + "..."
+	}
+	return strconv.QuoteToASCII(text)
+}
@@ -0,0 +1,49 @@
+package tmpltree
+
+import (
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{`plain`, `(list "\"plain\"")`},
+		{`{{.Name | printf "%q"}}`, `(list (action (pipe (command .Name) (command printf "\"%q\""))))`},
+		{`{{if .A}}a{{else}}b{{end}}`, `(list (if (pipe (command .A)) (then/else (list "\"a\"") (list "\"b\""))))`},
+		{`{{range $i, $x := .Items}}{{$x}}{{break}}{{end}}`, `(list (range ("pipe $i, $x :=" (command .Items)) (list (action (pipe (command $x))) break)))`},
+		{`{{range .}}{{with $v = .A.B}}{{continue}}{{end}}{{end}}`, `(list (range (pipe (command .)) (list (with ("pipe $v =" (command .A.B)) (list continue)))))`},
+		{`{{/* note */}}{{template "row" .}}`, `(list "comment \"/* note */\"" ("template \"row\"" (pipe (command .))))`},
+		{`{{(len .) | eq 3 true nil}}`, `(list (action (pipe (command (pipe (command len .))) (command (... eq 3) (... true nil)))))`},
+		{"line 1\nline 2 is a bit longer than that", `(list "\"line 1\\nline 2 is a bit l\"...")`},
+		{`{{define "x"}}X{{end}}{{template "x"}}`, `(templates ("define \"t\"" (list "template \"x\"")) ("define \"x\"" (list "\"X\"")))`},
+		{`{{block "b" .}}B{{end}}`, `(templates ("define \"t\"" (list ("template \"b\"" (pipe (command .))))) ("define \"b\"" (list "\"B\"")))`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse("t", tt.text)
+		if assert.NoError(t, err, tt.text) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.text)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{`{{if}}`, `template: t:1: missing value for if`},
+		{"a\n{{end}}", `template: t:2: unexpected {{end}}`},
+		{`{{.A`, `template: t:1: unclosed action`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse("t", tt.text)
+		assert.EqualError(t, err, tt.expected, tt.text)
+	}
+}
@@ -0,0 +1,117 @@
+// Package traversal reconstructs printer.Node trees from pairs of traversal sequences, and computes the traversals of trees.
+//
+// A binary tree is determined uniquely by its inorder sequence together with either the preorder or the postorder sequence,
+// as long as all the values are distinct.
+package traversal
+
+import (
+	"errors"
+	"fmt"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+var (
+	// ErrAmbiguous is returned when the sequences contain repeated values, so more than one tree could match them.
+	ErrAmbiguous = errors.New("ambiguous traversals")
+	// ErrInconsistent is returned when no tree matches the sequences.
+	ErrInconsistent = errors.New("inconsistent traversals")
+)
+
+// FromPreIn reconstructs the tree from its preorder and inorder sequences.
+func FromPreIn(preorder, inorder []string) (*printer.Node, error) {
+	return reconstruct(preorder, inorder, func(seq []string) (string, []string) { return seq[0], seq[1:] }, "preorder")
+}
+
+// FromPostIn reconstructs the tree from its postorder and inorder sequences.
+func FromPostIn(postorder, inorder []string) (*printer.Node, error) {
+	return reconstruct(postorder, inorder, func(seq []string) (string, []string) { return seq[len(seq)-1], seq[:len(seq)-1] }, "postorder")
+}
+
+// reconstruct builds the tree from the inorder sequence and the other sequence, which has the root either at the beginning or at the end.
+// The split function returns the root of the other sequence and the remaining values: the left subtree followed by the right subtree.
+func reconstruct(other, inorder []string, split func([]string) (string, []string), otherName string) (*printer.Node, error) {
+	if len(other) != len(inorder) {
+		return nil, fmt.Errorf("%w: the %s sequence has %d values, the inorder sequence has %d", ErrInconsistent, otherName, len(other), len(inorder))
+	}
+	if len(inorder) == 0 {
+		return nil, fmt.Errorf("%w: empty sequences", ErrInconsistent)
+	}
+
+	positions := map[string]int{}
+	for i, value := range inorder {
+		if value == "" {
+			return nil, fmt.Errorf("%w: empty value at the position %d of the inorder sequence", ErrInconsistent, i+1)
+		}
+		if _, ok := positions[value]; ok {
+			return nil, fmt.Errorf("%w: the value %q appears more than once", ErrAmbiguous, value)
+		}
+		positions[value] = i
+	}
+
+	var build func(seq []string, inStart int) (*printer.Node, error)
+	build = func(seq []string, inStart int) (*printer.Node, error) {
+		if len(seq) == 0 {
+			return nil, nil
+		}
+		value, rest := split(seq)
+		pos, ok := positions[value]
+		if !ok {
+			return nil, fmt.Errorf("%w: the value %q of the %s sequence is missing in the inorder sequence", ErrInconsistent, value, otherName)
+		}
+		if pos < inStart || pos >= inStart+len(seq) {
+			return nil, fmt.Errorf("%w: the value %q is in a different subtree in the inorder sequence", ErrInconsistent, value)
+		}
+
+		leftSize := pos - inStart
+		left, err := build(rest[:leftSize], inStart)
+		if err != nil {
+			return nil, err
+		}
+		right, err := build(rest[leftSize:], pos+1)
+		if err != nil {
+			return nil, err
+		}
+		return &printer.Node{Value: value, LeftChild: left, RightChild: right}, nil
+	}
+	return build(other, 0)
+}
+
+// Preorder returns the values of the tree in the preorder: the node, the left subtree, the right subtree.
+func Preorder(root *printer.Node) []string {
+	var result []string
+	walk(root, func(n *printer.Node) { result = append(result, n.Value) }, nil, nil)
+	return result
+}
+
+// Inorder returns the values of the tree in the inorder: the left subtree, the node, the right subtree.
+func Inorder(root *printer.Node) []string {
+	var result []string
+	walk(root, nil, func(n *printer.Node) { result = append(result, n.Value) }, nil)
+	return result
+}
+
+// Postorder returns the values of the tree in the postorder: the left subtree, the right subtree, the node.
+func Postorder(root *printer.Node) []string {
+	var result []string
+	walk(root, nil, nil, func(n *printer.Node) { result = append(result, n.Value) })
+	return result
+}
+
+// walk visits the nodes of the tree depth-first, calling the non-nil visit functions before, between and after visiting the subtrees of a node.
+func walk(n *printer.Node, pre, in, post func(*printer.Node)) {
+	if n == nil {
+		return
+	}
+	if pre != nil {
+		pre(n)
+	}
+	walk(n.LeftChild, pre, in, post)
+	if in != nil {
+		in(n)
+	}
+	walk(n.RightChild, pre, in, post)
+	if post != nil {
+		post(n)
+	}
+}
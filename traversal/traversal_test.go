@@ -0,0 +1,69 @@
+package traversal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconstruct(t *testing.T) {
+	trees := []string{
+		"x",
+		"(+ 1 2)",
+		"(- x)",
+		"(neg () x)",
+		"(root (+ 1 (foo (* 2 345) (bar 6789 9))) (- 5432 5))",
+		"(a (b (c (d))))",
+		"(a () (b () (c () d)))",
+	}
+
+	for _, tree := range trees {
+		root, err := sexpr.Parse(tree)
+		assert.NoError(t, err)
+
+		actual, err := FromPreIn(Preorder(root), Inorder(root))
+		if assert.NoError(t, err, tree) {
+			assert.Equal(t, root, actual, tree)
+		}
+
+		actual, err = FromPostIn(Postorder(root), Inorder(root))
+		if assert.NoError(t, err, tree) {
+			assert.Equal(t, root, actual, tree)
+		}
+	}
+}
+
+func TestTraversals(t *testing.T) {
+	root, err := sexpr.Parse("(1 (2 4 5) (3 () 6))")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1 2 4 5 3 6", strings.Join(Preorder(root), " "))
+	assert.Equal(t, "4 2 5 1 3 6", strings.Join(Inorder(root), " "))
+	assert.Equal(t, "4 5 2 6 3 1", strings.Join(Postorder(root), " "))
+	assert.Empty(t, Preorder(nil))
+}
+
+func TestReconstructErrors(t *testing.T) {
+	tests := []struct {
+		pre, in  string
+		sentinel error
+		expected string
+	}{
+		{"1 2", "1", ErrInconsistent, "inconsistent traversals: the preorder sequence has 2 values, the inorder sequence has 1"},
+		{"", "", ErrInconsistent, "inconsistent traversals: empty sequences"},
+		{"1 2 1", "1 2 1", ErrAmbiguous, `ambiguous traversals: the value "1" appears more than once`},
+		{"1 2 3", "2 1 4", ErrInconsistent, `inconsistent traversals: the value "3" of the preorder sequence is missing in the inorder sequence`},
+		{"1 2 3", "3 1 2", ErrInconsistent, `inconsistent traversals: the value "2" is in a different subtree in the inorder sequence`},
+	}
+
+	for _, tt := range tests {
+		_, err := FromPreIn(strings.Fields(tt.pre), strings.Fields(tt.in))
+		assert.ErrorIs(t, err, tt.sentinel, tt.pre)
+		assert.EqualError(t, err, tt.expected, tt.pre)
+	}
+
+	_, err := FromPostIn([]string{"2", "3", "1"}, []string{"3", "1", "2"})
+	assert.EqualError(t, err, `inconsistent traversals: the value "2" is in a different subtree in the inorder sequence`)
+}
@@ -0,0 +1,109 @@
+// Package treediff compares two printer.Node trees and shows the differences as a single merged tree.
+//
+// The trees are aligned by structure: nodes at the same position (the same path of left and right children from the root)
+// are compared with each other. A node only in the new tree is marked with AddedMark, a node only in the old tree
+// with RemovedMark, and a node with different values shows both, joined by ChangedMark, e.g. "+x", "-y" and "a->b".
+// The marks are plain ASCII, because the printer measures values in bytes. They can't be told from values that start
+// with the same characters, e.g. the operators of an expression, so DiffChanges also tells which nodes differ, e.g. to color them.
+package treediff
+
+import printer "github.com/ZupkaPomidorowa/print-tree"
+
+const (
+	// AddedMark is prepended to the values of nodes only in the new tree.
+	AddedMark = "+"
+	// RemovedMark is prepended to the values of nodes only in the old tree.
+	RemovedMark = "-"
+	// ChangedMark joins the old and the new value of a node.
+	ChangedMark = "->"
+	// CollapsedValue replaces unchanged subtrees with Options.Collapse.
+	// It differs from printer.GroupValue, so that the two can be told apart in the same drawing.
+	CollapsedValue = "(=)"
+)
+
+// Change is the kind of difference shown by a node of the merged tree.
+type Change int
+
+const (
+	Unchanged Change = iota
+	Added
+	Removed
+	Changed
+)
+
+// Options controls how the differences are shown.
+type Options struct {
+	// Collapse replaces every unchanged subtree that isn't a leaf with a single CollapsedValue leaf.
+	Collapse bool
+}
+
+// Summary counts the differences: the added and the removed nodes, and the nodes with changed values.
+type Summary struct {
+	Added, Removed, Changed int
+}
+
+// Diff returns the merged tree showing the differences between the old and the new tree, and the counts of the differences.
+// The tree is nil only if both trees are nil.
+func Diff(before, after *printer.Node, opts Options) (*printer.Node, Summary) {
+	root, summary, _ := DiffChanges(before, after, opts)
+	return root, summary
+}
+
+// DiffChanges returns the same as Diff(), and the kinds of the differences shown by the nodes of the merged tree.
+// The unchanged nodes are not in the map.
+func DiffChanges(before, after *printer.Node, opts Options) (*printer.Node, Summary, map[*printer.Node]Change) {
+	d := differ{opts: opts, changes: map[*printer.Node]Change{}}
+	root := d.diff(before, after)
+	return root, d.summary, d.changes
+}
+
+// differ holds the state of a comparison.
+type differ struct {
+	opts    Options
+	summary Summary
+	changes map[*printer.Node]Change
+}
+
+func (d *differ) diff(before, after *printer.Node) *printer.Node {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return d.marked(after, AddedMark, Added, &d.summary.Added)
+	case after == nil:
+		return d.marked(before, RemovedMark, Removed, &d.summary.Removed)
+	case d.opts.Collapse && !before.IsLeaf() && equal(before, after):
+		return &printer.Node{Value: CollapsedValue}
+	}
+
+	n := &printer.Node{Value: before.Value}
+	if before.Value != after.Value {
+		n.Value = before.Value + ChangedMark + after.Value
+		d.summary.Changed++
+		d.changes[n] = Changed
+	}
+	n.LeftChild = d.diff(before.LeftChild, after.LeftChild)
+	n.RightChild = d.diff(before.RightChild, after.RightChild)
+	return n
+}
+
+// marked returns a copy of the subtree with the mark prepended to every value, counting the nodes.
+func (d *differ) marked(n *printer.Node, mark string, change Change, count *int) *printer.Node {
+	if n == nil {
+		return nil
+	}
+	*count++
+	copied := &printer.Node{Value: mark + n.Value}
+	d.changes[copied] = change
+	copied.LeftChild = d.marked(n.LeftChild, mark, change, count)
+	copied.RightChild = d.marked(n.RightChild, mark, change, count)
+	return copied
+}
+
+// equal reports whether the subtrees have the same structure and values.
+func equal(a, b *printer.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value && equal(a.LeftChild, b.LeftChild) && equal(a.RightChild, b.RightChild)
+}
@@ -0,0 +1,74 @@
+package treediff
+
+import (
+	"testing"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		before, after string
+		collapse      bool
+		expected      string
+		summary       Summary
+	}{
+		{"(+ a b)", "(+ a b)", false, "(+ a b)", Summary{}},
+		{"(+ a b)", "(- a c)", false, "(+->- a b->c)", Summary{Changed: 2}},
+		{"(+ a)", "(+ a (* b c))", false, "(+ a (+* +b +c))", Summary{Added: 3}},
+		{"(+ (f x) y)", "(+ () y)", false, "(+ (-f -x) y)", Summary{Removed: 2}},
+		{"(+ (f x) y)", "(+ y (f x))", false, "(+ (f->y -x) (y->f +x))", Summary{Added: 1, Removed: 1, Changed: 2}},
+		{"(+ (* a b) (f x))", "(+ (* a b) (g x y))", true, `(+ "(=)" (f->g x +y))`, Summary{Added: 1, Changed: 1}},
+		{"(+ (* a b) c)", "(+ (* a b) c)", true, `"(=)"`, Summary{}},
+		{"(+ a b)", "(+ a c)", true, "(+ a b->c)", Summary{Changed: 1}},
+	}
+
+	for _, tt := range tests {
+		before, err := sexpr.Parse(tt.before)
+		assert.NoError(t, err)
+		after, err := sexpr.Parse(tt.after)
+		assert.NoError(t, err)
+
+		root, summary := Diff(before, after, Options{Collapse: tt.collapse})
+		assert.Equal(t, tt.expected, sexpr.Format(root), tt.before+" "+tt.after)
+		assert.Equal(t, tt.summary, summary, tt.before+" "+tt.after)
+	}
+}
+
+func TestDiffMissingTrees(t *testing.T) {
+	tree := &printer.Node{Value: "a", RightChild: &printer.Node{Value: "b"}}
+
+	root, summary := Diff(nil, tree, Options{})
+	assert.Equal(t, "(+a () +b)", sexpr.Format(root))
+	assert.Equal(t, Summary{Added: 2}, summary)
+
+	root, summary = Diff(tree, nil, Options{})
+	assert.Equal(t, "(-a () -b)", sexpr.Format(root))
+	assert.Equal(t, Summary{Removed: 2}, summary)
+
+	root, summary = Diff(nil, nil, Options{})
+	assert.Nil(t, root)
+	assert.Equal(t, Summary{}, summary)
+	assert.Equal(t, "a", tree.Value, "the trees are not modified")
+}
+
+func TestDiffChanges(t *testing.T) {
+	before, err := sexpr.Parse("(+ (- a) b)")
+	assert.NoError(t, err)
+	after, err := sexpr.Parse("(+ () (* () c))")
+	assert.NoError(t, err)
+
+	root, summary, changes := DiffChanges(before, after, Options{})
+	assert.Equal(t, "(+ (-- -a) (b->* () +c))", sexpr.Format(root))
+	assert.Equal(t, Summary{Added: 1, Removed: 2, Changed: 1}, summary)
+
+	// The unchanged "+" and the removed "-" are told apart, though both values start with a mark.
+	assert.Equal(t, map[*printer.Node]Change{
+		root.LeftChild:             Removed,
+		root.LeftChild.LeftChild:   Removed,
+		root.RightChild:            Changed,
+		root.RightChild.RightChild: Added,
+	}, changes)
+}
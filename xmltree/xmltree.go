@@ -0,0 +1,232 @@
+// Package xmltree converts XML documents into printer.Node trees, reading them with the encoding/xml token stream.
+//
+// Elements become nodes labelled with their names, and their content becomes the children, in the order of the document.
+// Text is collapsed: runs of white space are replaced by a single space, text consisting only of white space is skipped,
+// and the rest is shown quoted, e.g. `"Hello, world"`. Comments, processing instructions and directives are skipped.
+// Elements with more than two children are folded with printer.Group().
+package xmltree
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	printer "github.com/ZupkaPomidorowa/print-tree"
+)
+
+// Namespaces selects how the namespaces of element and attribute names are shown.
+type Namespaces int
+
+const (
+	// NamespacesHidden shows only the local names, e.g. "rect". The xmlns attributes are skipped.
+	NamespacesHidden Namespaces = iota
+	// NamespacesPrefix shows the names with the prefixes used in the document, e.g. "svg:rect".
+	NamespacesPrefix
+	// NamespacesURI shows the names with the namespace URIs, e.g. "{http://www.w3.org/2000/svg}rect".
+	NamespacesURI
+)
+
+// ElidedMark is appended to the labels of elements whose content is not shown because of Options.MaxDepth.
+const ElidedMark = " ..."
+
+// Options controls how a document is converted.
+type Options struct {
+	// Attributes adds the attributes of an element as its first children, labelled e.g. `@width="10"`.
+	Attributes bool
+	// MaxDepth limits the levels of elements below the root element. Zero means no limit.
+	MaxDepth int
+	// MaxText is the maximum number of characters of text (and attribute values) shown in a node.
+	// Longer text is cut and ends with "...". Zero means no limit.
+	MaxText int
+	// Namespaces selects how the namespaces are shown.
+	Namespaces Namespaces
+}
+
+// Parse reads an XML document and converts its root element. The document is read to the end,
+// and anything but white space, comments and processing instructions after the root element is an error.
+func Parse(r io.Reader, opts Options) (*printer.Node, error) {
+	p := parser{dec: xml.NewDecoder(r), opts: opts}
+	for {
+		tok, err := p.dec.Token()
+		if err == io.EOF {
+			return nil, errors.New("no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			root, err := p.element(start, 0, nil)
+			if err != nil {
+				return nil, err
+			}
+			return root, p.end()
+		}
+	}
+}
+
+type parser struct {
+	dec  *xml.Decoder
+	opts Options
+}
+
+// end reads the rest of the document after the root element.
+func (p *parser) end() error {
+	for {
+		tok, err := p.dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := p.dec.InputPos()
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			return &xml.SyntaxError{Msg: fmt.Sprintf("element <%s> after the root element", tok.Name.Local), Line: line}
+		case xml.CharData:
+			if strings.TrimSpace(string(tok)) != "" {
+				return &xml.SyntaxError{Msg: "text after the root element", Line: line}
+			}
+		}
+	}
+}
+
+// element reads the content of an element, up to its end, and returns its node.
+// The prefixes map namespace URIs to the prefixes declared by the ancestors.
+func (p *parser) element(start xml.StartElement, depth int, prefixes map[string]string) (*printer.Node, error) {
+	prefixes = declare(prefixes, start.Attr)
+	elided := p.opts.MaxDepth > 0 && depth >= p.opts.MaxDepth
+
+	var children []*printer.Node
+	if p.opts.Attributes && !elided {
+		for _, attr := range start.Attr {
+			if p.opts.Namespaces == NamespacesHidden && isNamespaceDeclaration(attr.Name) {
+				continue
+			}
+			children = append(children, &printer.Node{Value: "@" + p.attrName(attr.Name, prefixes) + "=" + p.quote(attr.Value)})
+		}
+	}
+
+	hasContent := false
+	for {
+		tok, err := p.dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			hasContent = true
+			if elided {
+				if err := p.dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			child, err := p.element(tok, depth+1, prefixes)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		case xml.CharData:
+			text := strings.Join(strings.Fields(string(tok)), " ")
+			if text == "" {
+				continue
+			}
+			hasContent = true
+			if !elided {
+				children = append(children, &printer.Node{Value: p.quote(text)})
+			}
+		case xml.EndElement:
+			label := p.name(start.Name, prefixes)
+			if elided && (hasContent || p.opts.Attributes && len(start.Attr) > 0) {
+				label += ElidedMark
+			}
+			return printer.Group(label, children...), nil
+		}
+	}
+}
+
+// name returns the name of an element as selected by Options.Namespaces.
+func (p *parser) name(name xml.Name, prefixes map[string]string) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	switch p.opts.Namespaces {
+	case NamespacesPrefix:
+		if prefix := prefixes[name.Space]; prefix != "" {
+			return prefix + ":" + name.Local
+		}
+	case NamespacesURI:
+		return "{" + name.Space + "}" + name.Local
+	}
+	return name.Local
+}
+
+// attrName returns the name of an attribute. The namespace declarations are shown as they were written, e.g. "xmlns:svg".
+func (p *parser) attrName(name xml.Name, prefixes map[string]string) string {
+	if name.Space == "xmlns" {
+		return "xmlns:" + name.Local
+	}
+	return p.name(name, prefixes)
+}
+
+func (p *parser) quote(text string) string {
+	if runes := []rune(text); p.opts.MaxText > 0 && len(runes) > p.opts.MaxText {
+		return strconv.QuoteToASCII(string(runes[:p.opts.MaxText])) + "..."
+	}
+	return strconv.QuoteToASCII(text)
+}
+
+func isNamespaceDeclaration(name xml.Name) bool {
+	return name.Space == "xmlns" || name.Space == "" && name.Local == "xmlns"
+}
+
+// declare returns the prefixes extended with the namespaces declared by the attributes.
+// The map is copied only if there are new declarations, because most elements don't have any.
+func declare(prefixes map[string]string, attrs []xml.Attr) map[string]string {
+	copied := false
+	for _, attr := range attrs {
+		if attr.Name.Space != "xmlns" {
+			continue
+		}
+		if !copied {
+			extended := make(map[string]string, len(prefixes)+1)
+			for uri, prefix := range prefixes {
+				extended[uri] = prefix
+			}
+			prefixes, copied = extended, true
+		}
+		prefixes[attr.Value] = attr.Name.Local
+	}
+	return prefixes
+}
+
+// String returns the name of the namespace display mode, as accepted by ParseNamespaces.
+func (n Namespaces) String() string {
+	switch n {
+	case NamespacesHidden:
+		return "hidden"
+	case NamespacesPrefix:
+		return "prefix"
+	case NamespacesURI:
+		return "uri"
+	}
+	return fmt.Sprintf("Namespaces(%d)", int(n))
+}
+
+// ParseNamespaces returns the namespace display mode with the given name: "hidden", "prefix" or "uri".
+func ParseNamespaces(s string) (Namespaces, error) {
+	for n := NamespacesHidden; n <= NamespacesURI; n++ {
+		if n.String() == s {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown namespace mode %q, expected hidden, prefix or uri", s)
+}
@@ -0,0 +1,79 @@
+package xmltree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZupkaPomidorowa/print-tree/sexpr"
+	"github.com/stretchr/testify/assert"
+)
+
+const svg = `<?xml version="1.0"?>
+<!-- a comment -->
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xl="http://www.w3.org/1999/xlink" width="10">
+  <g id="a"><rect/><a xl:href="#x">  Hello,
+     world  </a></g>
+  <title>T</title>
+</svg>`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		expected string
+	}{
+		{"defaults", Options{}, `(svg (g rect (a "\"Hello, world\"")) (title "\"T\""))`},
+		{"attributes", Options{Attributes: true},
+			`(svg (... "@width=\"10\"" (g (... "@id=\"a\"" rect) (a "@href=\"#x\"" "\"Hello, world\""))) (title "\"T\""))`},
+		{"prefixes", Options{Attributes: true, Namespaces: NamespacesPrefix},
+			`(svg (... (... "@xmlns=\"http://www.w3.org/2000/svg\"" "@xmlns:xl=\"http://www.w3.org/1999/xlink\"") "@width=\"10\"") ` +
+				`(... (g (... "@id=\"a\"" rect) (a "@xl:href=\"#x\"" "\"Hello, world\"")) (title "\"T\"")))`},
+		{"URIs", Options{Namespaces: NamespacesURI},
+			`({http://www.w3.org/2000/svg}svg ({http://www.w3.org/2000/svg}g {http://www.w3.org/2000/svg}rect ` +
+				`({http://www.w3.org/2000/svg}a "\"Hello, world\"")) ({http://www.w3.org/2000/svg}title "\"T\""))`},
+		{"depth", Options{MaxDepth: 1}, `(svg "g ..." "title ...")`},
+		{"empty elements at max depth", Options{MaxDepth: 2}, `(svg (g rect "a ...") (title "\"T\""))`},
+		{"text", Options{MaxText: 3}, `(svg (g rect (a "\"Hel\"...")) (title "\"T\""))`},
+	}
+
+	for _, tt := range tests {
+		actual, err := Parse(strings.NewReader(svg), tt.opts)
+		if assert.NoError(t, err, tt.name) {
+			assert.Equal(t, tt.expected, sexpr.Format(actual), tt.name)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{``, `no root element`},
+		{`just text`, `no root element`},
+		{`<a>`, `XML syntax error on line 1: unexpected EOF`},
+		{"<a>\n<b></a>", `XML syntax error on line 2: element <b> closed by </a>`},
+		{"<a/>\n<b/>", `XML syntax error on line 2: element <b> after the root element`},
+		{"<a></a>\ntrailing", `XML syntax error on line 2: text after the root element`},
+		{"<a></a></b>", `XML syntax error on line 1: unexpected end element </b>`},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.input), Options{})
+		assert.EqualError(t, err, tt.expected, tt.input)
+	}
+
+	// White space, comments and processing instructions may follow the root element.
+	_, err := Parse(strings.NewReader("<a></a>\n<!-- done -->\n<?end?>\n"), Options{})
+	assert.NoError(t, err)
+}
+
+func TestParseNamespaces(t *testing.T) {
+	for _, n := range []Namespaces{NamespacesHidden, NamespacesPrefix, NamespacesURI} {
+		parsed, err := ParseNamespaces(n.String())
+		assert.NoError(t, err)
+		assert.Equal(t, n, parsed)
+	}
+	_, err := ParseNamespaces("full")
+	assert.EqualError(t, err, `unknown namespace mode "full", expected hidden, prefix or uri`)
+}